@@ -37,6 +37,7 @@ import (
 	"k8s.io/kubectl/pkg/util/templates"
 
 	"github.com/applejag/kubectl-klock/pkg/klock"
+	"github.com/applejag/kubectl-klock/pkg/table"
 	"github.com/applejag/kubectl-klock/pkg/types"
 )
 
@@ -126,21 +127,93 @@ Use "kubectl api-resources" for a complete list of supported resources.`,
 
 	o.Kubecolor = kubecolorConfig
 	o.HideDeleted = types.NewOptionalDuration(10 * time.Second)
+	o.SnapshotFormat = string(table.ExportText)
+	o.NotifyVia = []string{string(klock.NotifyBell), string(klock.NotifyOSC9)}
+	o.OnEventConcurrency = 4
+	o.OnEventTimeout = 30 * time.Second
+	o.ChunkSize = 500
+	o.CoalesceWindow = 50 * time.Millisecond
+	o.MetricsInterval = 15 * time.Second
+	o.SparklineInterval = 10 * time.Second
+	o.TimeFormat = string(table.TimeRelative)
 
 	o.ConfigFlags = kubeConfigFlags
 	o.ConfigFlags.AddFlags(root.PersistentFlags())
+	if contextFlag := root.PersistentFlags().Lookup("context"); contextFlag != nil {
+		contextFlag.Usage = "The name of the kubeconfig context to use. Watch multiple contexts at once by separating them with commas, or by using glob patterns, such as --context 'dev,prod-*'."
+	}
 
+	root.Flags().Bool("all-contexts", o.AllContexts, "Watch all contexts in the kubeconfig at once, with a CONTEXT column. Combine with --context to only watch the contexts that match a glob pattern, such as --context 'prod-*'.")
 	root.Flags().BoolP("all-namespaces", "A", o.AllNamespaces, "If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	root.Flags().Int64("chunk-size", o.ChunkSize, "Return large lists in chunks rather than all at once. Pass 0 to disable. The first chunk is shown while the rest are loaded.")
+	root.Flags().Duration("coalesce-window", o.CoalesceWindow, "Wait this long after a watch event for more events, and redraw the table once for all of them. Set to \"0\" to redraw on every event.")
+	root.Flags().Bool("conditions", o.Conditions, "Add a CONDITIONS column with the object's .status.conditions, with Ready and Available first.")
+	root.Flags().Bool("debug", o.Debug, "Show debug information in the status line, such as how many bytes have been received from the API server.")
+	root.Flags().StringArray("extra-column", o.ExtraColumns, "Add a column with the value of a JSONPath expression on the object, as NAME=JSONPATH, or NAME=JSONPATH:duration to show durations as a live age. Example: --extra-column NODE=.spec.nodeName. Can be specified multiple times.")
 	root.Flags().String("field-selector", o.FieldSelector, "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
+	root.Flags().String("clipboard-file", o.ClipboardFile, "Write copied text to this file instead of using the OSC 52 terminal escape sequence. Defaults to a file in the user's cache directory when stderr is not a terminal.")
+	root.Flags().Bool("metrics", o.Metrics, "Show CPU and memory usage columns when watching pods or nodes, polled from metrics-server. Percentages are of the pods' requests and limits, or of the nodes' allocatable resources.")
+	root.Flags().Duration("metrics-interval", o.MetricsInterval, "How often to poll metrics-server when using --metrics.")
+	root.Flags().StringSlice("notify", o.Notify, "Send a notification when a row enters an error status (error), when all READY fractions are complete (ready), or when a row is deleted (deleted). Example: --notify=error,deleted")
+	root.Flags().StringSlice("notify-via", o.NotifyVia, "How to deliver notifications: ring the terminal bell (bell), or send a desktop notification via the OSC 9 (osc9) or OSC 777 (osc777) terminal escape sequences.")
+	root.Flags().String("notify-command", o.NotifyCommand, "Command to run for every notification, with the event as JSON on stdin. Runs through \"sh -c\", or \"cmd /C\" on Windows.")
+	root.Flags().StringArray("on-event", o.OnEvent, "Command to run for every watch event, with the object as JSON on stdin, and the event type and row in environment variables. Can be specified multiple times.")
+	root.Flags().StringSlice("on-event-types", o.OnEventTypes, "Only run the --on-event commands for these event types. One or more of: added, modified, deleted.")
+	root.Flags().String("on-event-filter", o.OnEventFilter, "Only run the --on-event commands for rows that contain this text.")
+	root.Flags().Int("on-event-concurrency", o.OnEventConcurrency, "Maximum number of --on-event commands to run at the same time.")
+	root.Flags().Duration("on-event-timeout", o.OnEventTimeout, "Kill --on-event commands that run for longer than this duration.")
 	root.Flags().StringP("output", "o", o.Output, "Output format. Only a small subset of formats found in 'kubectl get' are supported by kubectl-klock.")
+	root.Flags().String("time-format", o.TimeFormat, "How to show times, such as in the AGE column, one of: relative, absolute, both. Can be changed with the T key.")
+	root.Flags().String("timezone", o.Timezone, "Time zone used for absolute times, such as \"UTC\" or \"Europe/Stockholm\". Defaults to the local time zone.")
 	root.Flags().BoolP("watch-kubeconfig", "W", o.WatchKubeconfig, "Restart the watch when the kubeconfig file changes.")
 	root.Flags().StringSliceP("label-columns", "L", o.LabelColumns, "Accepts a comma separated list of labels that are going to be presented as columns.")
+	root.Flags().StringSlice("annotation-columns", o.AnnotationColumns, "Accepts a comma separated list of annotations that are going to be presented as columns.")
+	root.Flags().Bool("read-only", o.ReadOnly, "Disable all actions that modify resources, such as deleting or scaling them.")
+	root.Flags().String("snapshot-format", o.SnapshotFormat, "Default format used when exporting rows, one of: text, csv, json, markdown.")
+	root.Flags().String("sparkline", o.Sparkline, "Show the recent history of a value as a sparkline in a TREND column, one of: restarts, ready, cpu, memory. The cpu and memory sources require --metrics.")
+	root.Flags().Duration("sparkline-interval", o.SparklineInterval, "How often to sample the --sparkline value, in addition to whenever the row is updated.")
 	root.Flags().Var(&o.HideDeleted, "hide-deleted", `Hide deleted elements after this duration. Example: "10s", "1m". Set to "0" to always hide, and "false" to show forever.`)
 	cmdutil.AddLabelSelectorFlagVar(root, &o.LabelSelector)
 
 	root.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"wide"}, cobra.ShellCompDirectiveNoFileComp
 	})
+	root.RegisterFlagCompletionFunc("notify", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var rules []string
+		for _, rule := range klock.NotifyRules {
+			rules = append(rules, string(rule))
+		}
+		return rules, cobra.ShellCompDirectiveNoFileComp
+	})
+	root.RegisterFlagCompletionFunc("notify-via", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var methods []string
+		for _, method := range klock.NotifyMethods {
+			methods = append(methods, string(method))
+		}
+		return methods, cobra.ShellCompDirectiveNoFileComp
+	})
+	root.RegisterFlagCompletionFunc("snapshot-format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var formats []string
+		for _, format := range table.ExportFormats {
+			formats = append(formats, string(format))
+		}
+		return formats, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	root.RegisterFlagCompletionFunc("time-format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var formats []string
+		for _, format := range table.TimeFormats {
+			formats = append(formats, string(format))
+		}
+		return formats, cobra.ShellCompDirectiveNoFileComp
+	})
+	root.RegisterFlagCompletionFunc("sparkline", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var sources []string
+		for _, source := range klock.SparklineSources {
+			sources = append(sources, string(source))
+		}
+		return sources, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	registerCompletionFuncForGlobalFlags(root, f)
 
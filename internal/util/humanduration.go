@@ -20,6 +20,7 @@ package util
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 )
 
@@ -66,6 +67,27 @@ func ParseHumanDuration(s string) (time.Duration, bool) {
 	return dur, true
 }
 
+// ParseHumanTime parses a time relative to now, as formatted by
+// [k8s.io/apimachinery/pkg/util/duration.HumanDuration], where "3d" means
+// 3 days ago, while "in 3d" means 3 days from now.
+func ParseHumanTime(s string) (time.Time, bool) {
+	future, isFuture := strings.CutPrefix(s, "in ")
+	if isFuture {
+		s = future
+	}
+	if s == "" {
+		return time.Time{}, false
+	}
+	dur, ok := ParseHumanDuration(s)
+	if !ok {
+		return time.Time{}, false
+	}
+	if isFuture {
+		return nowFunc().Add(dur), true
+	}
+	return nowFunc().Add(-dur), true
+}
+
 func parseHumanDurationSegment(s string) (num int, char rune, rest string, ok bool) {
 	n, err := fmt.Sscanf(s, "%d%c%s", &num, &char, &rest)
 	ok = (err == io.EOF && n == 2) || err == nil
@@ -72,6 +72,61 @@ func TestParseHumanDurationSegment(t *testing.T) {
 	}
 }
 
+func TestParseHumanTime(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	nowFunc = func() time.Time { return now }
+	defer func() { nowFunc = time.Now }()
+
+	tests := []struct {
+		input string
+		time  time.Time
+		ok    bool
+	}{
+		{
+			input: "3d",
+			time:  now.AddDate(0, 0, -3),
+			ok:    true,
+		},
+		{
+			input: "in 3d",
+			time:  now.AddDate(0, 0, 3),
+			ok:    true,
+		},
+		{
+			input: "in 2h10m",
+			time:  now.Add(2*time.Hour + 10*time.Minute),
+			ok:    true,
+		},
+		{
+			input: "in ",
+			ok:    false,
+		},
+		{
+			input: "",
+			ok:    false,
+		},
+		{
+			input: "in 3 days",
+			ok:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, ok := ParseHumanTime(tc.input)
+			if tc.ok != ok {
+				t.Fatalf("want ok=%t, got ok=%t & time=%s", tc.ok, ok, got)
+			}
+			if !tc.ok {
+				return
+			}
+			if !tc.time.Equal(got) {
+				t.Errorf("want time=%s, got time=%s", tc.time, got)
+			}
+		})
+	}
+}
+
 func TestParseHumanDuration(t *testing.T) {
 	const (
 		DAY = time.Hour * 24
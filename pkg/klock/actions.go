@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// action is an operation that can be performed on a row's object,
+// such as deleting it.
+type action struct {
+	Name string
+	// Input, if set, is the prompt used to ask for a value from the user
+	// before confirming the action.
+	Input string
+	// InputName is a short name of the input, used in the confirmation.
+	InputName string
+	// InputDefault, if set, returns the initial value of the input for
+	// the object, such as its current number of replicas.
+	InputDefault func(obj *rowObject) string
+	// Run performs the action. The input is the value entered by the user,
+	// or an empty string if the action does not use any input.
+	Run func(ctx context.Context, c *kubeClients, obj *rowObject, input string) error
+}
+
+type kubeClients struct {
+	Typed   kubernetes.Interface
+	Dynamic dynamic.Interface
+}
+
+func newKubeClients(configFlags *genericclioptions.ConfigFlags) (*kubeClients, error) {
+	restConfig, err := configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	typed, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dyn, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &kubeClients{Typed: typed, Dynamic: dyn}, nil
+}
+
+func (c *kubeClients) resource(obj *rowObject) dynamic.ResourceInterface {
+	res := c.Dynamic.Resource(obj.Resource)
+	if obj.Namespaced {
+		return res.Namespace(obj.Object.GetNamespace())
+	}
+	return res
+}
+
+// actionsFor returns the actions that can be performed on the object.
+func actionsFor(obj *rowObject) []action {
+	actions := []action{actionDelete}
+	switch {
+	case obj.Is("apps", "Deployment"),
+		obj.Is("apps", "StatefulSet"):
+		actions = append(actions, actionRolloutRestart, actionScale)
+	case obj.Is("apps", "DaemonSet"):
+		actions = append(actions, actionRolloutRestart)
+	case obj.Is("apps", "ReplicaSet"),
+		obj.Is("", "ReplicationController"):
+		actions = append(actions, actionScale)
+	case obj.Is("", "Node"):
+		actions = append(actions, actionCordon, actionUncordon, actionDrain)
+	case obj.Is("batch", "CronJob"):
+		actions = append(actions, actionTriggerJob, actionSuspend, actionResume)
+	}
+	return actions
+}
+
+var actionDelete = action{
+	Name:      "Delete",
+	Input:     "Grace period in seconds (empty for default):",
+	InputName: "grace period",
+	Run: func(ctx context.Context, c *kubeClients, obj *rowObject, input string) error {
+		var opts metav1.DeleteOptions
+		if input = strings.TrimSpace(input); input != "" {
+			seconds, err := strconv.ParseInt(input, 10, 64)
+			if err != nil || seconds < 0 {
+				return fmt.Errorf("invalid grace period: %q", input)
+			}
+			opts.GracePeriodSeconds = &seconds
+		}
+		return c.resource(obj).Delete(ctx, obj.Object.GetName(), opts)
+	},
+}
+
+var actionRolloutRestart = action{
+	Name: "Rollout restart",
+	Run: func(ctx context.Context, c *kubeClients, obj *rowObject, _ string) error {
+		// Same annotation as "kubectl rollout restart" uses
+		return mergePatch(ctx, c, obj, map[string]any{
+			"spec": map[string]any{
+				"template": map[string]any{
+					"metadata": map[string]any{
+						"annotations": map[string]any{
+							"kubectl.kubernetes.io/restartedAt": time.Now().Format(time.RFC3339),
+						},
+					},
+				},
+			},
+		})
+	},
+}
+
+// scalableResources are the resources that get [actionScale].
+var scalableResources = []schema.GroupResource{
+	{Group: "apps", Resource: "deployments"},
+	{Group: "apps", Resource: "statefulsets"},
+	{Group: "apps", Resource: "replicasets"},
+	{Group: "", Resource: "replicationcontrollers"},
+}
+
+var actionScale = action{
+	Name:      "Scale",
+	Input:     "Number of replicas:",
+	InputName: "replicas",
+	// Only set when the rows contain the full objects,
+	// see [Printer.needsFullObject]
+	InputDefault: func(obj *rowObject) string {
+		replicas, ok, _ := unstructured.NestedInt64(obj.Object.Object, "spec", "replicas")
+		if !ok {
+			return ""
+		}
+		return strconv.FormatInt(replicas, 10)
+	},
+	Run: func(ctx context.Context, c *kubeClients, obj *rowObject, input string) error {
+		replicas, err := strconv.ParseInt(strings.TrimSpace(input), 10, 32)
+		if err != nil || replicas < 0 {
+			return fmt.Errorf("invalid number of replicas: %q", input)
+		}
+		return mergePatch(ctx, c, obj, map[string]any{
+			"spec": map[string]any{"replicas": replicas},
+		})
+	},
+}
+
+var actionCordon = action{
+	Name: "Cordon",
+	Run: func(ctx context.Context, c *kubeClients, obj *rowObject, _ string) error {
+		return setUnschedulable(ctx, c, obj, true)
+	},
+}
+
+var actionUncordon = action{
+	Name: "Uncordon",
+	Run: func(ctx context.Context, c *kubeClients, obj *rowObject, _ string) error {
+		return setUnschedulable(ctx, c, obj, false)
+	},
+}
+
+func setUnschedulable(ctx context.Context, c *kubeClients, obj *rowObject, unschedulable bool) error {
+	return mergePatch(ctx, c, obj, map[string]any{
+		"spec": map[string]any{"unschedulable": unschedulable},
+	})
+}
+
+// actionDrain is a simplified version of "kubectl drain", which cordons
+// the node and then evicts all pods from it, except for DaemonSet and
+// static pods. It does not wait for the pods to be terminated.
+var actionDrain = action{
+	Name: "Drain (cordon and evict pods)",
+	Run: func(ctx context.Context, c *kubeClients, obj *rowObject, _ string) error {
+		if err := setUnschedulable(ctx, c, obj, true); err != nil {
+			return fmt.Errorf("cordon: %w", err)
+		}
+		pods, err := c.Typed.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", obj.Object.GetName()).String(),
+		})
+		if err != nil {
+			return fmt.Errorf("list pods: %w", err)
+		}
+		var errs []error
+		for _, pod := range pods.Items {
+			if !shouldEvictPod(&pod) {
+				continue
+			}
+			err := c.Typed.CoreV1().Pods(pod.Namespace).EvictV1(ctx, &policyv1.Eviction{
+				ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name},
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("evict pod %s/%s: %w", pod.Namespace, pod.Name, err))
+			}
+		}
+		return errors.Join(errs...)
+	},
+}
+
+func shouldEvictPod(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, isMirror := pod.Annotations[corev1.MirrorPodAnnotationKey]; isMirror {
+		return false
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
+
+var actionSuspend = action{
+	Name: "Suspend",
+	Run: func(ctx context.Context, c *kubeClients, obj *rowObject, _ string) error {
+		return mergePatch(ctx, c, obj, map[string]any{
+			"spec": map[string]any{"suspend": true},
+		})
+	},
+}
+
+var actionResume = action{
+	Name: "Resume",
+	Run: func(ctx context.Context, c *kubeClients, obj *rowObject, _ string) error {
+		return mergePatch(ctx, c, obj, map[string]any{
+			"spec": map[string]any{"suspend": false},
+		})
+	},
+}
+
+// actionTriggerJob creates a Job from a CronJob, like
+// "kubectl create job --from=cronjob/NAME".
+var actionTriggerJob = action{
+	Name: "Trigger job now",
+	Run: func(ctx context.Context, c *kubeClients, obj *rowObject, _ string) error {
+		cronJobs := c.Typed.BatchV1().CronJobs(obj.Object.GetNamespace())
+		cronJob, err := cronJobs.Get(ctx, obj.Object.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("get cronjob: %w", err)
+		}
+		job := newJobFromCronJob(cronJob)
+		_, err = c.Typed.BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{})
+		return err
+	},
+}
+
+func newJobFromCronJob(cronJob *batchv1.CronJob) *batchv1.Job {
+	annotations := map[string]string{
+		"cronjob.kubernetes.io/instantiate": "manual",
+	}
+	for k, v := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+	// Job names are limited to 63 characters
+	name := fmt.Sprintf("%s-manual-%s", cronJob.Name, rand.String(5))
+	if len(name) > 63 {
+		name = fmt.Sprintf("%s-manual-%s", cronJob.Name[:63-len("-manual-")-5], rand.String(5))
+	}
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   cronJob.Namespace,
+			Annotations: annotations,
+			Labels:      cronJob.Spec.JobTemplate.Labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+}
+
+func mergePatch(ctx context.Context, c *kubeClients, obj *rowObject, patch map[string]any) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	_, err = c.resource(obj).Patch(ctx, obj.Object.GetName(), apitypes.MergePatchType, data, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,298 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func newTestRowObject(gvr schema.GroupVersionResource, kind, namespace, name string) *rowObject {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(gvr.GroupVersion().String())
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return &rowObject{
+		GVK:        gvr.GroupVersion().WithKind(kind),
+		Resource:   gvr,
+		Namespaced: namespace != "",
+		Object:     obj,
+	}
+}
+
+var (
+	gvrPods        = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	gvrNodes       = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+	gvrDeployments = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	gvrCronJobs    = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}
+)
+
+func actionNames(actions []action) string {
+	var names []string
+	for _, act := range actions {
+		names = append(names, act.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestActionsFor(t *testing.T) {
+	tests := []struct {
+		obj  *rowObject
+		want string
+	}{
+		{
+			obj:  newTestRowObject(gvrPods, "Pod", "default", "my-pod"),
+			want: "Delete",
+		},
+		{
+			obj:  newTestRowObject(gvrDeployments, "Deployment", "default", "my-deploy"),
+			want: "Delete,Rollout restart,Scale",
+		},
+		{
+			obj:  newTestRowObject(gvrNodes, "Node", "", "my-node"),
+			want: "Delete,Cordon,Uncordon,Drain (cordon and evict pods)",
+		},
+		{
+			obj:  newTestRowObject(gvrCronJobs, "CronJob", "default", "my-cronjob"),
+			want: "Delete,Trigger job now,Suspend,Resume",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.obj.GVK.Kind, func(t *testing.T) {
+			if got := actionNames(actionsFor(test.obj)); got != test.want {
+				t.Errorf("wrong actions\nwant: %q\ngot:  %q", test.want, got)
+			}
+		})
+	}
+}
+
+func newTestKubeClients(objects ...*rowObject) *kubeClients {
+	dyn := dynamicfake.NewSimpleDynamicClient(scheme.Scheme)
+	for _, obj := range objects {
+		dyn.Tracker().Create(obj.Resource, obj.Object, obj.Object.GetNamespace())
+	}
+	return &kubeClients{Typed: fake.NewClientset(), Dynamic: dyn}
+}
+
+func TestActionDelete(t *testing.T) {
+	obj := newTestRowObject(gvrPods, "Pod", "default", "my-pod")
+	clients := newTestKubeClients(obj)
+
+	if err := actionDelete.Run(context.Background(), clients, obj, "invalid"); err == nil {
+		t.Fatal("want error for invalid grace period")
+	}
+	if err := actionDelete.Run(context.Background(), clients, obj, "5"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := clients.Dynamic.Resource(gvrPods).Namespace("default").Get(context.Background(), "my-pod", metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("want not found error, got: %v", err)
+	}
+}
+
+func TestActionScale(t *testing.T) {
+	obj := newTestRowObject(gvrDeployments, "Deployment", "default", "my-deploy")
+	clients := newTestKubeClients(obj)
+
+	if err := actionScale.Run(context.Background(), clients, obj, "3"); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := clients.Dynamic.Resource(gvrDeployments).Namespace("default").Get(context.Background(), "my-deploy", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	replicas, _, _ := unstructured.NestedInt64(updated.Object, "spec", "replicas")
+	if replicas != 3 {
+		t.Errorf("want 3 replicas, got %d", replicas)
+	}
+}
+
+func TestActionCordon(t *testing.T) {
+	obj := newTestRowObject(gvrNodes, "Node", "", "my-node")
+	clients := newTestKubeClients(obj)
+
+	if err := actionCordon.Run(context.Background(), clients, obj, ""); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := clients.Dynamic.Resource(gvrNodes).Get(context.Background(), "my-node", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unschedulable, _, _ := unstructured.NestedBool(updated.Object, "spec", "unschedulable")
+	if !unschedulable {
+		t.Error("want node to be unschedulable")
+	}
+}
+
+func TestActionTriggerJob(t *testing.T) {
+	obj := newTestRowObject(gvrCronJobs, "CronJob", "default", "my-cronjob")
+	clients := newTestKubeClients()
+	clients.Typed = fake.NewClientset(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-cronjob", UID: "cronjob-uid"},
+		Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "my-app"}},
+			},
+		},
+	})
+
+	if err := actionTriggerJob.Run(context.Background(), clients, obj, ""); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err := clients.Typed.BatchV1().Jobs("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs.Items) != 1 {
+		t.Fatalf("want 1 job, got %d", len(jobs.Items))
+	}
+	job := jobs.Items[0]
+	if !strings.HasPrefix(job.Name, "my-cronjob-manual-") {
+		t.Errorf("wrong job name: %q", job.Name)
+	}
+	if got := job.Annotations["cronjob.kubernetes.io/instantiate"]; got != "manual" {
+		t.Errorf("want instantiate annotation %q, got %q", "manual", got)
+	}
+	if got := job.Labels["app"]; got != "my-app" {
+		t.Errorf("want label from job template, got %q", got)
+	}
+	if owner := metav1.GetControllerOf(&job); owner == nil || owner.UID != "cronjob-uid" {
+		t.Errorf("want job to be owned by cronjob, got: %v", owner)
+	}
+}
+
+func TestShouldEvictPod(t *testing.T) {
+	isController := true
+	tests := []struct {
+		name string
+		pod  corev1.Pod
+		want bool
+	}{
+		{
+			name: "regular pod",
+			pod:  corev1.Pod{},
+			want: true,
+		},
+		{
+			name: "daemonset pod",
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{
+				{Kind: "DaemonSet", Controller: &isController},
+			}}},
+			want: false,
+		},
+		{
+			name: "mirror pod",
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				corev1.MirrorPodAnnotationKey: "",
+			}}},
+			want: false,
+		},
+		{
+			name: "completed pod",
+			pod:  corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+			want: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := shouldEvictPod(&test.pod); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestActionsPaneConfirmation(t *testing.T) {
+	obj := newTestRowObject(gvrPods, "Pod", "default", "my-pod")
+	clients := newTestKubeClients(obj)
+	styles := table.DefaultStyles
+	p := newActionsPane(clients, &styles, &DefaultKeyMap, []*rowObject{obj})
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	p.Update(enter) // choose "Delete"
+	if p.state != actionsPaneInput {
+		t.Fatalf("want input state, got %d", p.state)
+	}
+	p.Update(enter) // use default grace period
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if p.state != actionsPaneChoosing {
+		t.Fatalf("want aborted back to choosing, got %d", p.state)
+	}
+
+	p.Update(enter)
+	p.Update(enter)
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("want command to run the action")
+	}
+	p.Update(cmd())
+	if p.err != nil {
+		t.Fatal(p.err)
+	}
+	if got, want := p.View(80, 10), "Delete: done for pod/my-pod"; !strings.Contains(got, want) {
+		t.Errorf("want view to contain %q, got:\n%s", want, got)
+	}
+}
+
+func TestActionScaleInputDefault(t *testing.T) {
+	newDeploy := func(name string, replicas int64) *rowObject {
+		obj := newTestRowObject(gvrDeployments, "Deployment", "default", name)
+		unstructured.SetNestedField(obj.Object.Object, replicas, "spec", "replicas")
+		return obj
+	}
+	tests := []struct {
+		name    string
+		objects []*rowObject
+		want    string
+	}{
+		{name: "single", objects: []*rowObject{newDeploy("a", 3)}, want: "3"},
+		{name: "same for all", objects: []*rowObject{newDeploy("a", 2), newDeploy("b", 2)}, want: "2"},
+		{name: "different", objects: []*rowObject{newDeploy("a", 2), newDeploy("b", 5)}, want: ""},
+		{name: "metadata only", objects: []*rowObject{newTestRowObject(gvrDeployments, "Deployment", "default", "a")}, want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			styles := table.DefaultStyles
+			p := newActionsPane(newTestKubeClients(), &styles, &DefaultKeyMap, tc.objects)
+			p.cursor = slices.IndexFunc(p.actions, func(a action) bool { return a.Name == actionScale.Name })
+			p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			if p.state != actionsPaneInput {
+				t.Fatalf("want input state, got %d", p.state)
+			}
+			if got := p.input.Value(); got != tc.want {
+				t.Errorf("want input %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+// actionTimeout is the maximum time an action may take before it is aborted.
+const actionTimeout = 30 * time.Second
+
+type actionsPaneState int
+
+const (
+	actionsPaneChoosing actionsPaneState = iota
+	actionsPaneInput
+	actionsPaneConfirming
+	actionsPaneRunning
+	actionsPaneDone
+)
+
+// actionsPane is a menu of actions that can be performed on the selected
+// row, where each action has to be confirmed before it is performed.
+type actionsPane struct {
+	styles  *table.Styles
+	keys    *KeyMap
+	clients *kubeClients
+	objects []*rowObject
+	actions []action
+
+	state  actionsPaneState
+	cursor int
+	input  textinput.Model
+	result string
+	err    error
+}
+
+type actionsPaneResultMsg struct {
+	pane   *actionsPane
+	result string
+	err    error
+}
+
+var _ table.InputPane = &actionsPane{}
+
+// openActionsPane returns a pane for performing actions on all of the rows
+// at once, such as when multiple rows are marked.
+func openActionsPane(configFlags *genericclioptions.ConfigFlags, styles *table.Styles, keys *KeyMap, rows []table.Row) table.Pane {
+	var objects []*rowObject
+	for _, row := range rows {
+		if obj, ok := rowObjectOf(row); ok {
+			objects = append(objects, obj)
+		}
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+	for _, obj := range objects[1:] {
+		if obj.Context != objects[0].Context {
+			err := fmt.Errorf("marked rows are from multiple contexts: %s and %s", objects[0].Context, obj.Context)
+			return &actionsPane{styles: styles, keys: keys, err: err, state: actionsPaneDone}
+		}
+	}
+	clients, err := newKubeClients(objects[0].configFlagsOr(configFlags))
+	if err != nil {
+		return &actionsPane{styles: styles, keys: keys, err: err, state: actionsPaneDone}
+	}
+	return newActionsPane(clients, styles, keys, objects)
+}
+
+func newActionsPane(clients *kubeClients, styles *table.Styles, keys *KeyMap, objects []*rowObject) *actionsPane {
+	input := textinput.New()
+	input.Prompt = "> "
+	return &actionsPane{
+		styles:  styles,
+		keys:    keys,
+		clients: clients,
+		objects: objects,
+		actions: actionsFor(objects[0]),
+		input:   input,
+	}
+}
+
+func (p *actionsPane) Init() tea.Cmd {
+	return nil
+}
+
+func (p *actionsPane) Close() {}
+
+func (p *actionsPane) CapturesInput() bool {
+	return p.state == actionsPaneInput || p.state == actionsPaneConfirming
+}
+
+func (p *actionsPane) Update(msg tea.Msg) (table.Pane, tea.Cmd) {
+	switch msg := msg.(type) {
+	case actionsPaneResultMsg:
+		if msg.pane == p {
+			p.state = actionsPaneDone
+			p.result = msg.result
+			p.err = msg.err
+		}
+	case tea.KeyMsg:
+		return p, p.updateKey(msg)
+	}
+	return p, nil
+}
+
+func (p *actionsPane) updateKey(msg tea.KeyMsg) tea.Cmd {
+	switch p.state {
+	case actionsPaneChoosing:
+		switch {
+		case key.Matches(msg, p.keys.MenuUp):
+			p.cursor = max(p.cursor-1, 0)
+		case key.Matches(msg, p.keys.MenuDown):
+			p.cursor = min(p.cursor+1, len(p.actions)-1)
+		case key.Matches(msg, p.keys.MenuSelect):
+			act := p.actions[p.cursor]
+			if act.Input != "" {
+				p.state = actionsPaneInput
+				p.input.SetValue(p.inputDefault(act))
+				return p.input.Focus()
+			}
+			p.state = actionsPaneConfirming
+		}
+	case actionsPaneInput:
+		switch {
+		case key.Matches(msg, p.keys.MenuSelect):
+			p.input.Blur()
+			p.state = actionsPaneConfirming
+		case key.Matches(msg, p.keys.MenuCancel):
+			p.input.Blur()
+			p.state = actionsPaneChoosing
+		default:
+			var cmd tea.Cmd
+			p.input, cmd = p.input.Update(msg)
+			return cmd
+		}
+	case actionsPaneConfirming:
+		switch {
+		case key.Matches(msg, p.keys.Confirm):
+			p.state = actionsPaneRunning
+			return p.run(p.actions[p.cursor], p.inputValue())
+		default:
+			// Anything else than an explicit "yes" aborts the action
+			p.state = actionsPaneChoosing
+		}
+	case actionsPaneDone:
+		if key.Matches(msg, p.keys.MenuSelect) && p.clients != nil {
+			p.state = actionsPaneChoosing
+			p.result = ""
+			p.err = nil
+		}
+	}
+	return nil
+}
+
+// inputDefault returns the initial input value of the action, if it's the
+// same for all of the objects.
+func (p *actionsPane) inputDefault(act action) string {
+	if act.InputDefault == nil {
+		return ""
+	}
+	value := act.InputDefault(p.objects[0])
+	for _, obj := range p.objects[1:] {
+		if act.InputDefault(obj) != value {
+			return ""
+		}
+	}
+	return value
+}
+
+func (p *actionsPane) inputValue() string {
+	if p.actions[p.cursor].Input == "" {
+		return ""
+	}
+	return p.input.Value()
+}
+
+func (p *actionsPane) run(act action, input string) tea.Cmd {
+	clients := p.clients
+	objects := p.objects
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+		defer cancel()
+		var errs []error
+		for _, obj := range objects {
+			if err := act.Run(ctx, clients, obj, input); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", objectRef(obj), err))
+			}
+		}
+		var result string
+		if succeeded := len(objects) - len(errs); succeeded > 0 {
+			result = fmt.Sprintf("%s: done for %s", act.Name, p.describeObjects(succeeded))
+		}
+		return actionsPaneResultMsg{pane: p, result: result, err: errors.Join(errs...)}
+	}
+}
+
+// objectRef returns a short reference to the object, such as "pod/my-pod".
+func objectRef(obj *rowObject) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(obj.GVK.Kind), obj.Object.GetName())
+}
+
+func (p *actionsPane) describeObjects(count int) string {
+	switch {
+	case count != len(p.objects):
+		return fmt.Sprintf("%d of %d objects", count, len(p.objects))
+	case count == 1:
+		return objectRef(p.objects[0])
+	default:
+		return fmt.Sprintf("%d objects", count)
+	}
+}
+
+func (p *actionsPane) View(width, height int) string {
+	var sb strings.Builder
+	sb.WriteString(p.styles.PaneTitle.Render("ACTIONS:"))
+	if len(p.objects) > 0 {
+		sb.WriteByte(' ')
+		sb.WriteString(p.describeObjects(len(p.objects)))
+	}
+	sb.WriteByte('\n')
+
+	switch p.state {
+	case actionsPaneChoosing:
+		for i, act := range p.actions {
+			if i > 0 {
+				sb.WriteByte('\n')
+			}
+			if i == p.cursor {
+				sb.WriteString(p.styles.Row.Selected.Render("> " + act.Name))
+			} else {
+				sb.WriteString("  " + act.Name)
+			}
+		}
+	case actionsPaneInput:
+		act := p.actions[p.cursor]
+		sb.WriteString(act.Name)
+		sb.WriteString(": ")
+		sb.WriteString(act.Input)
+		sb.WriteByte('\n')
+		sb.WriteString(p.input.View())
+	case actionsPaneConfirming:
+		act := p.actions[p.cursor]
+		prompt := fmt.Sprintf("%s %s?", act.Name, p.describeObjects(len(p.objects)))
+		if input := p.inputValue(); input != "" {
+			prompt = fmt.Sprintf("%s %s (%s: %s)?", act.Name, p.describeObjects(len(p.objects)), act.InputName, input)
+		}
+		sb.WriteString(p.styles.FilterPrompt.UnsetString().Render(prompt))
+		sb.WriteString(" [y/N]")
+	case actionsPaneRunning:
+		sb.WriteString(p.styles.Toggles.Render(p.actions[p.cursor].Name + "…"))
+	case actionsPaneDone:
+		if p.result != "" {
+			sb.WriteString(p.result)
+		}
+		if p.err != nil {
+			if p.result != "" {
+				sb.WriteByte('\n')
+			}
+			sb.WriteString(p.styles.Error.Render(p.err.Error()))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	"golang.org/x/term"
+)
+
+// clipboard copies text using the OSC 52 terminal escape sequence, which
+// also works over SSH, or by writing the text to a file as a fallback.
+type clipboard struct {
+	// Output is where the OSC 52 escape sequences are written to.
+	Output io.Writer
+	// File, if set, is where the text is written to instead of using OSC 52.
+	File string
+}
+
+// newClipboard returns a clipboard that writes to stderr, as Bubble Tea is
+// rendering to stdout. If stderr is not a terminal, then the text is
+// written to a file in the user's cache directory instead.
+func newClipboard(file string) *clipboard {
+	if file == "" && !term.IsTerminal(int(os.Stderr.Fd())) {
+		file, _ = defaultClipboardFile()
+	}
+	return &clipboard{Output: os.Stderr, File: file}
+}
+
+// defaultClipboardFile returns a file that only the current user can write
+// to, as a fixed path in a shared directory (such as /tmp) could be
+// replaced with a symlink by another user.
+func defaultClipboardFile() (string, error) {
+	if dir, err := os.UserCacheDir(); err == nil {
+		dir = filepath.Join(dir, "kubectl-klock")
+		if err := os.MkdirAll(dir, 0o700); err == nil {
+			return filepath.Join(dir, "clipboard.txt"), nil
+		}
+	}
+	// Unique file with 0600 permissions
+	f, err := os.CreateTemp("", "klock-clipboard-*.txt")
+	if err != nil {
+		return "", err
+	}
+	f.Close()
+	return f.Name(), nil
+}
+
+// Copy copies the text, and returns a description of where it was
+// copied to, such as "the clipboard".
+func (c *clipboard) Copy(text string) (string, error) {
+	if c.File != "" {
+		if err := os.WriteFile(c.File, []byte(text), 0o600); err != nil {
+			return "", fmt.Errorf("write to file: %w", err)
+		}
+		return c.File, nil
+	}
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	if _, err := seq.WriteTo(c.Output); err != nil {
+		return "", fmt.Errorf("copy to clipboard: %w", err)
+	}
+	return "the clipboard", nil
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func wantOSC52(text string) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+}
+
+func TestClipboardCopy(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+
+	t.Run("osc52", func(t *testing.T) {
+		var buf bytes.Buffer
+		cb := &clipboard{Output: &buf}
+		dest, err := cb.Copy("my-pod")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dest != "the clipboard" {
+			t.Errorf("wrong destination: %q", dest)
+		}
+		if got, want := buf.String(), wantOSC52("my-pod"); got != want {
+			t.Errorf("wrong OSC 52 sequence\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		var buf bytes.Buffer
+		file := filepath.Join(t.TempDir(), "clipboard.txt")
+		cb := &clipboard{Output: &buf, File: file}
+		dest, err := cb.Copy("my-pod")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dest != file {
+			t.Errorf("wrong destination: %q", dest)
+		}
+		if buf.Len() > 0 {
+			t.Errorf("want no OSC 52 sequence, got: %q", buf.String())
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != "my-pod" {
+			t.Errorf("wrong file content: %q", content)
+		}
+	})
+}
+
+func TestDefaultClipboardFile(t *testing.T) {
+	t.Run("cache dir", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		t.Setenv("XDG_CACHE_HOME", cacheDir)
+		t.Setenv("HOME", cacheDir)
+		file, err := defaultClipboardFile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(file, cacheDir) {
+			t.Errorf("want file in cache dir %q, got %q", cacheDir, file)
+		}
+		stat, err := os.Stat(filepath.Dir(file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := stat.Mode().Perm(), os.FileMode(0o700); got != want {
+			t.Errorf("want dir permissions %s, got %s", want, got)
+		}
+	})
+
+	t.Run("temp file fallback", func(t *testing.T) {
+		tempDir := t.TempDir()
+		t.Setenv("TMPDIR", tempDir)
+		t.Setenv("XDG_CACHE_HOME", "")
+		t.Setenv("HOME", "")
+		file, err := defaultClipboardFile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Dir(file) != tempDir || file == filepath.Join(tempDir, "klock-clipboard.txt") {
+			t.Errorf("want unique file in %q, got %q", tempDir, file)
+		}
+		stat, err := os.Stat(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := stat.Mode().Perm(), os.FileMode(0o600); got != want {
+			t.Errorf("want file permissions %s, got %s", want, got)
+		}
+	})
+}
+
+func TestCopyFormats(t *testing.T) {
+	pod := newTestRowObject(gvrPods, "Pod", "default", "my-pod")
+	deploy := newTestRowObject(gvrDeployments, "Deployment", "default", "my-deploy")
+	node := newTestRowObject(gvrNodes, "Node", "", "my-node")
+	prodPod := newTestRowObject(gvrPods, "Pod", "default", "my-pod")
+	prodPod.Context = "prod"
+
+	tests := []struct {
+		obj    *rowObject
+		format copyFormat
+		want   string
+	}{
+		{obj: pod, format: copyName, want: "my-pod"},
+		{obj: pod, format: copyNamespacedName, want: "default/my-pod"},
+		{obj: pod, format: copyKindName, want: "pod/my-pod"},
+		{obj: deploy, format: copyKindName, want: "deployment.apps/my-deploy"},
+		{obj: pod, format: copyKubectlDescribe, want: "kubectl -n default describe pod my-pod"},
+		{obj: node, format: copyKubectlDescribe, want: "kubectl describe node my-node"},
+		{obj: deploy, format: copyKubectlGetYAML, want: "kubectl -n default get deployment.apps my-deploy -o yaml"},
+		{obj: pod, format: copyKubectlLogs, want: "kubectl -n default logs my-pod"},
+		{obj: prodPod, format: copyKubectlLogs, want: "kubectl --context prod -n default logs my-pod"},
+		{obj: prodPod, format: copyName, want: "my-pod"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if got := test.format.Format(test.obj); got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+
+	if got := len(copyFormatsFor(node)); got != 4 {
+		t.Errorf("want 4 copy formats for cluster-scoped non-pod, got %d", got)
+	}
+}
+
+func TestCopyPaneMarkedRows(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+	var buf bytes.Buffer
+	styles := table.DefaultStyles
+	rows := []table.Row{
+		{Object: newTestRowObject(gvrPods, "Pod", "default", "pod-a")},
+		{Object: newTestRowObject(gvrPods, "Pod", "default", "pod-b")},
+	}
+	p := openCopyPane(&styles, &DefaultKeyMap, &clipboard{Output: &buf}, rows)
+	if p == nil {
+		t.Fatal("want a pane")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if got, want := p.View(80, 10), "Copied 2 lines to the clipboard"; !strings.Contains(got, want) {
+		t.Errorf("want view to contain %q, got:\n%s", want, got)
+	}
+	if got, want := buf.String(), wantOSC52("default/pod-a\ndefault/pod-b"); got != want {
+		t.Errorf("wrong OSC 52 sequence\nwant: %q\ngot:  %q", want, got)
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// eventBatch collects watch events received within a short window, so they
+// can be printed with a single redraw. Only the latest state of each object
+// is kept, except that deletions are never dropped.
+type eventBatch struct {
+	events []observedEvent
+	// index is the index in events of each object's latest event,
+	// keyed on its UID.
+	index map[string]int
+}
+
+// observedEvent is a watch event along with when it was received.
+type observedEvent struct {
+	watch.Event
+	Time time.Time
+}
+
+// Add adds the event to the batch, received at the given time.
+func (b *eventBatch) Add(event watch.Event, now time.Time) {
+	observed := observedEvent{Event: event, Time: now}
+	uid := eventUID(event.Object)
+	if uid == "" {
+		b.events = append(b.events, observed)
+		return
+	}
+	if i, ok := b.index[uid]; ok {
+		switch prev := b.events[i]; {
+		case prev.Type == watch.Deleted:
+			// Keep the deletion, and print the new event after it.
+		case prev.Type == watch.Added && event.Type != watch.Deleted:
+			// The object is still new to the table.
+			observed.Type = watch.Added
+			b.events[i] = observed
+			return
+		default:
+			b.events[i] = observed
+			return
+		}
+	}
+	if b.index == nil {
+		b.index = map[string]int{}
+	}
+	b.index[uid] = len(b.events)
+	b.events = append(b.events, observed)
+}
+
+// Flush returns the collected events in the order they were first received,
+// and empties the batch.
+func (b *eventBatch) Flush() []observedEvent {
+	events := b.events
+	b.events = nil
+	clear(b.index)
+	return events
+}
+
+// eventUID returns the UID of the object in the watch event, or an empty
+// string if it doesn't have one.
+func eventUID(obj runtime.Object) string {
+	if unstr, ok := obj.(*unstructured.Unstructured); ok && isTable(obj) {
+		rows, _, _ := unstructured.NestedSlice(unstr.Object, "rows")
+		if len(rows) != 1 {
+			return ""
+		}
+		row, ok := rows[0].(map[string]any)
+		if !ok {
+			return ""
+		}
+		uid, _, _ := unstructured.NestedString(row, "object", "metadata", "uid")
+		return uid
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return string(accessor.GetUID())
+}
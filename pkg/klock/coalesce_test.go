@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func newTestEvent(eventType watch.EventType, uid, phase string) watch.Event {
+	return watch.Event{
+		Type: eventType,
+		Object: &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "meta.k8s.io/v1",
+			"kind":       "Table",
+			"rows": []any{map[string]any{
+				"cells": []any{phase},
+				"object": map[string]any{
+					"metadata": map[string]any{"uid": uid},
+				},
+			}},
+		}},
+	}
+}
+
+// eventString formats the event as "TYPE uid phase", for easier comparison.
+func eventString(event watch.Event) string {
+	unstr := event.Object.(*unstructured.Unstructured)
+	rows, _, _ := unstructured.NestedSlice(unstr.Object, "rows")
+	row := rows[0].(map[string]any)
+	cells := row["cells"].([]any)
+	return string(event.Type) + " " + eventUID(event.Object) + " " + cells[0].(string)
+}
+
+func TestEventBatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []watch.Event
+		want   []string
+	}{
+		{
+			name: "latest state per uid",
+			events: []watch.Event{
+				newTestEvent(watch.Modified, "a", "Pending"),
+				newTestEvent(watch.Modified, "b", "Pending"),
+				newTestEvent(watch.Modified, "a", "Running"),
+			},
+			want: []string{"MODIFIED a Running", "MODIFIED b Pending"},
+		},
+		{
+			name: "added stays added",
+			events: []watch.Event{
+				newTestEvent(watch.Added, "a", "Pending"),
+				newTestEvent(watch.Modified, "a", "Running"),
+			},
+			want: []string{"ADDED a Running"},
+		},
+		{
+			name: "deleted replaces earlier events",
+			events: []watch.Event{
+				newTestEvent(watch.Added, "a", "Pending"),
+				newTestEvent(watch.Modified, "a", "Running"),
+				newTestEvent(watch.Deleted, "a", "Terminating"),
+			},
+			want: []string{"DELETED a Terminating"},
+		},
+		{
+			name: "deleted is never replaced",
+			events: []watch.Event{
+				newTestEvent(watch.Deleted, "a", "Terminating"),
+				newTestEvent(watch.Modified, "b", "Running"),
+				newTestEvent(watch.Modified, "a", "Running"),
+				newTestEvent(watch.Modified, "a", "Succeeded"),
+			},
+			want: []string{"DELETED a Terminating", "MODIFIED b Running", "MODIFIED a Succeeded"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var batch eventBatch
+			now := time.Now()
+			for _, event := range tc.events {
+				batch.Add(event, now)
+			}
+			var got []string
+			for _, event := range batch.Flush() {
+				got = append(got, eventString(event.Event))
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+			if len(batch.events) != 0 {
+				t.Errorf("want empty batch after flush, got %d events", len(batch.events))
+			}
+		})
+	}
+}
+
+func TestEventBatchKeepsEventTimes(t *testing.T) {
+	start := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	var batch eventBatch
+	batch.Add(newTestEvent(watch.Added, "a", "Pending"), start)
+	batch.Add(newTestEvent(watch.Modified, "b", "Pending"), start.Add(10*time.Millisecond))
+	batch.Add(newTestEvent(watch.Modified, "a", "Running"), start.Add(20*time.Millisecond))
+
+	events := batch.Flush()
+	want := []time.Time{start.Add(20 * time.Millisecond), start.Add(10 * time.Millisecond)}
+	if len(events) != len(want) {
+		t.Fatalf("want %d events, got %d", len(want), len(events))
+	}
+	for i, event := range events {
+		if !event.Time.Equal(want[i]) {
+			t.Errorf("event %d (%s): want time %s, got %s", i, eventString(event.Event), want[i], event.Time)
+		}
+	}
+}
@@ -74,7 +74,39 @@ func StatusColumn(status string) any {
 	return column
 }
 
+// StatusLevel is the severity of a status, used to decide its color.
+type StatusLevel int
+
+const (
+	StatusLevelDefault StatusLevel = iota
+	StatusLevelOK
+	StatusLevelWarning
+	StatusLevelError
+	StatusLevelNull
+	StatusLevelTrue
+	StatusLevelFalse
+)
+
 func StatusStyle(status string) lipgloss.Style {
+	switch StatusLevelOf(status) {
+	case StatusLevelOK:
+		return StyleStatusOK
+	case StatusLevelWarning:
+		return StyleStatusWarning
+	case StatusLevelError:
+		return StyleStatusError
+	case StatusLevelNull:
+		return StyleStatusNull
+	case StatusLevelTrue:
+		return StyleStatusTrue
+	case StatusLevelFalse:
+		return StyleStatusFalse
+	default:
+		return StyleStatusDefault
+	}
+}
+
+func StatusLevelOf(status string) StatusLevel {
 	switch status {
 	case
 		// from https://github.com/kubernetes/kubernetes/blob/master/pkg/kubelet/events/event.go
@@ -143,7 +175,7 @@ func StatusStyle(status string) lipgloss.Style {
 		"StartError",
 		// PVC status
 		"Lost":
-		return StyleStatusError
+		return StatusLevelError
 	case
 		// from https://github.com/kubernetes/kubernetes/blob/master/pkg/kubelet/events/event.go
 		// Container event reason list
@@ -184,7 +216,7 @@ func StatusStyle(status string) lipgloss.Style {
 		"Released",
 
 		"ScalingReplicaSet":
-		return StyleStatusWarning
+		return StatusLevelWarning
 	case
 		"Running",
 		"Completed",
@@ -205,18 +237,18 @@ func StatusStyle(status string) lipgloss.Style {
 
 		// PVC status
 		"Bound":
-		return StyleStatusOK
+		return StatusLevelOK
 
 	// Also allow some data-related values, common in CRD statuses (e.g. READY column with True/False)
 	case "null", "<none>", "<unknown>", "<unset>", "<nil>", "<invalid>":
-		return StyleStatusNull
+		return StatusLevelNull
 	case "true", "True", "TRUE":
-		return StyleStatusTrue
+		return StatusLevelTrue
 	case "false", "False", "FALSE":
-		return StyleStatusFalse
+		return StatusLevelFalse
 	}
 	// some ok status, not colored:
 	// "SandboxChanged",
 	// "Pulling",
-	return StyleStatusDefault
+	return StatusLevelDefault
 }
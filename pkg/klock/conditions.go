@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+// Conditions of these types are shown first, as they summarize the others.
+var summaryConditionTypes = []string{"Ready", "Available"}
+
+type condition struct {
+	Type               string
+	Status             string
+	LastTransitionTime time.Time
+}
+
+// parseConditions returns the object's .status.conditions, with the
+// summary conditions (e.g Ready) first.
+func parseConditions(obj map[string]any) []condition {
+	items, _, _ := unstructured.NestedSlice(obj, "status", "conditions")
+	conditions := make([]condition, 0, len(items))
+	for _, item := range items {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		var cond condition
+		cond.Type, _, _ = unstructured.NestedString(fields, "type")
+		cond.Status, _, _ = unstructured.NestedString(fields, "status")
+		if cond.Type == "" {
+			continue
+		}
+		if ts, _, _ := unstructured.NestedString(fields, "lastTransitionTime"); ts != "" {
+			cond.LastTransitionTime, _ = time.Parse(time.RFC3339, ts)
+		}
+		conditions = append(conditions, cond)
+	}
+	slices.SortStableFunc(conditions, func(a, b condition) int {
+		return conditionRank(a) - conditionRank(b)
+	})
+	return conditions
+}
+
+func conditionRank(cond condition) int {
+	if index := slices.Index(summaryConditionTypes, cond.Type); index != -1 {
+		return index
+	}
+	return len(summaryConditionTypes)
+}
+
+// String returns the condition's type, followed by its status
+// if it's not True, e.g "Ready" or "Ready=False".
+func (c condition) String() string {
+	if c.Status == "True" {
+		return c.Type
+	}
+	return c.Type + "=" + c.Status
+}
+
+func conditionStyle(status string) lipgloss.Style {
+	switch status {
+	case "True":
+		return StyleStatusTrue
+	case "False":
+		return StyleStatusFalse
+	default:
+		return StyleStatusNull
+	}
+}
+
+// ConditionsColumn renders the object's conditions as colored badges, where
+// the first one also shows how long ago it last changed. Deleted objects
+// get no coloring, to not add colors to a grayed-out row.
+func ConditionsColumn(obj map[string]any, deleted bool) any {
+	conditions := parseConditions(obj)
+	if len(conditions) == 0 {
+		return "<none>"
+	}
+	if deleted {
+		names := make([]string, len(conditions))
+		for i, cond := range conditions {
+			names[i] = cond.String()
+		}
+		return strings.Join(names, " ")
+	}
+	column := table.JoinedColumn{Delimiter: " "}
+	for i, cond := range conditions {
+		var value any = cond.String()
+		if i == 0 && !cond.LastTransitionTime.IsZero() {
+			value = table.AgoColumn{Value: cond.String(), Time: cond.LastTransitionTime}
+		}
+		column.Values = append(column.Values, table.StyledColumn{
+			Value: value,
+			Style: conditionStyle(cond.Status),
+		})
+	}
+	return column
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func TestConditionsColumn(t *testing.T) {
+	obj := map[string]any{
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Progressing", "status": "True"},
+				map[string]any{"type": "Synced", "status": "Unknown"},
+				map[string]any{"type": "Ready", "status": "False", "lastTransitionTime": "2026-01-02T12:00:00Z"},
+			},
+		},
+	}
+
+	if got, want := ConditionsColumn(obj, true), "Ready=False Progressing Synced=Unknown"; got != want {
+		t.Errorf("wrong deleted value\nwant: %q\ngot:  %#v", want, got)
+	}
+
+	column, ok := ConditionsColumn(obj, false).(table.JoinedColumn)
+	if !ok {
+		t.Fatalf("want joined column, got %T", column)
+	}
+	if len(column.Values) != 3 {
+		t.Fatalf("want 3 badges, got %d", len(column.Values))
+	}
+	first := column.Values[0].(table.StyledColumn)
+	ago, ok := first.Value.(table.AgoColumn)
+	if !ok {
+		t.Fatalf("want first badge to be an ago column, got %T", first.Value)
+	}
+	if want := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC); !ago.Time.Equal(want) {
+		t.Errorf("want last transition time %s, got %s", want, ago.Time)
+	}
+}
+
+func TestConditionsColumnNone(t *testing.T) {
+	if got := ConditionsColumn(map[string]any{}, false); got != "<none>" {
+		t.Errorf("want %q, got %#v", "<none>", got)
+	}
+}
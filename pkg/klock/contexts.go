@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// watchContexts returns the kubeconfig contexts to watch at the same time,
+// as selected by "--context a,b,c" or "--all-contexts". Returns nil when
+// only a single context should be watched.
+func (o Options) watchContexts() ([]string, error) {
+	var contextFlag string
+	if o.ConfigFlags.Context != nil {
+		contextFlag = *o.ConfigFlags.Context
+	}
+	if !o.AllContexts && !isMultiContextFlag(contextFlag) {
+		return nil, nil
+	}
+	rawConfig, err := o.ConfigFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("read kubeconfig: %w", err)
+	}
+	available := make([]string, 0, len(rawConfig.Contexts))
+	for name := range rawConfig.Contexts {
+		available = append(available, name)
+	}
+	return selectContexts(available, contextFlag)
+}
+
+// isMultiContextFlag returns true if the --context flag value is a comma
+// separated list or glob pattern, instead of a single context name.
+func isMultiContextFlag(contextFlag string) bool {
+	return strings.ContainsAny(contextFlag, ",*?[")
+}
+
+// selectContexts returns the contexts matched by the comma separated names
+// or glob patterns, in the order they were given. An empty selection
+// returns all contexts.
+func selectContexts(available []string, selection string) ([]string, error) {
+	available = slices.Sorted(slices.Values(available))
+	var selected []string
+	for spec := range strings.SplitSeq(selection, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		if !strings.ContainsAny(spec, "*?[") {
+			if !slices.Contains(available, spec) {
+				return nil, fmt.Errorf("context %q not found in kubeconfig", spec)
+			}
+			if !slices.Contains(selected, spec) {
+				selected = append(selected, spec)
+			}
+			continue
+		}
+		if _, err := path.Match(spec, ""); err != nil {
+			return nil, fmt.Errorf("context pattern %q: %w", spec, err)
+		}
+		for _, name := range available {
+			if ok, _ := path.Match(spec, name); ok && !slices.Contains(selected, name) {
+				selected = append(selected, name)
+			}
+		}
+	}
+	if strings.TrimSpace(strings.ReplaceAll(selection, ",", "")) == "" {
+		selected = available
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no contexts in kubeconfig match %q", selection)
+	}
+	return selected, nil
+}
+
+// configFlagsForContext returns a copy of the config flags that uses the
+// given kubeconfig context.
+func configFlagsForContext(base *genericclioptions.ConfigFlags, context string) *genericclioptions.ConfigFlags {
+	flags := genericclioptions.NewConfigFlags(false)
+	flags.CacheDir = base.CacheDir
+	flags.KubeConfig = base.KubeConfig
+	flags.ClusterName = base.ClusterName
+	flags.AuthInfoName = base.AuthInfoName
+	flags.Context = &context
+	flags.Namespace = base.Namespace
+	flags.APIServer = base.APIServer
+	flags.TLSServerName = base.TLSServerName
+	flags.Insecure = base.Insecure
+	flags.CertFile = base.CertFile
+	flags.KeyFile = base.KeyFile
+	flags.CAFile = base.CAFile
+	flags.BearerToken = base.BearerToken
+	flags.Impersonate = base.Impersonate
+	flags.ImpersonateUID = base.ImpersonateUID
+	flags.ImpersonateGroup = base.ImpersonateGroup
+	flags.ImpersonateUserExtra = base.ImpersonateUserExtra
+	flags.Username = base.Username
+	flags.Password = base.Password
+	flags.Timeout = base.Timeout
+	flags.DisableCompression = base.DisableCompression
+	flags.WrapConfigFn = base.WrapConfigFn
+	return flags
+}
+
+// forContext returns a copy of the printer for watching the given context.
+// The contexts are watched concurrently, so anything stateful is created
+// anew instead of being shared with the other contexts.
+func (p Printer) forContext(o Options, context string) Printer {
+	p.Context = context
+	// Each context keeps track of its own rows
+	p.Notifier, _ = o.newNotifier()
+	if p.Notifier != nil {
+		t := p.Table
+		p.Notifier.OnError = func(err error) { t.LogError("notify", err) }
+	}
+	p.Hooks = p.Hooks.forContext()
+	if o.Metrics {
+		p.Metrics = &metricsStore{}
+	}
+	// A JSONPath keeps state while evaluating, so it can't be shared
+	p.ExtraColumns, _ = parseExtraColumns(o.ExtraColumns)
+	return p
+}
+
+// setStatus shows the watcher's status in the status line, next to its
+// context. Only shown when watching multiple contexts.
+func (w *Watcher) setStatus(status string) {
+	if w.Printer.Context != "" {
+		w.Printer.Table.SetSourceStatus(w.Printer.Context, status, nil)
+	}
+}
+
+// logError adds the error to the error log, and shows it next to the
+// watcher's context when watching multiple contexts.
+func (w *Watcher) logError(err error) {
+	source := "watch"
+	if w.Printer.Context != "" {
+		source = w.Printer.Context
+		w.Printer.Table.SetSourceStatus(w.Printer.Context, "error", err)
+	}
+	w.Printer.Table.LogError(source, err)
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func TestSelectContexts(t *testing.T) {
+	available := []string{"prod-eu", "dev", "prod-us", "staging"}
+	tests := []struct {
+		name      string
+		selection string
+		want      []string
+		wantErr   bool
+	}{
+		{name: "all", selection: "", want: []string{"dev", "prod-eu", "prod-us", "staging"}},
+		{name: "list", selection: "staging,dev", want: []string{"staging", "dev"}},
+		{name: "pattern", selection: "prod-*", want: []string{"prod-eu", "prod-us"}},
+		{name: "duplicates", selection: "prod-us,prod-*", want: []string{"prod-us", "prod-eu"}},
+		{name: "spaces", selection: "dev, staging", want: []string{"dev", "staging"}},
+		{name: "unknown", selection: "dev,qa", wantErr: true},
+		{name: "no match", selection: "qa-*", wantErr: true},
+		{name: "bad pattern", selection: "prod-[", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := selectContexts(available, tc.selection)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("want error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsMultiContextFlag(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "", want: false},
+		{value: "prod", want: false},
+		{value: "dev,prod", want: true},
+		{value: "prod-*", want: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			if got := isMultiContextFlag(tc.value); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPrintObjContexts(t *testing.T) {
+	tbl := table.New()
+	newPrinter := func(context string) Printer {
+		return Printer{
+			Table:    tbl,
+			Context:  context,
+			fallback: &tableFallback{Clients: newTestFallbackClients(), GVR: gvrWidgets},
+		}
+	}
+	prod, dev := newPrinter("prod"), newPrinter("dev")
+	// Same object in both clusters
+	for _, p := range []*Printer{&prod, &dev} {
+		if _, err := p.PrintObj(newTestWidget("my-widget", 3), watch.Added); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := tbl.RowIndex("prod/uid-my-widget"); got != 1 {
+		t.Errorf("want prod row sorted after dev row, got index %d", got)
+	}
+	// The selection stays on the first added row
+	row, ok := tbl.SelectedRow()
+	if !ok {
+		t.Fatal("want a row")
+	}
+	if got, want := row.PlainFields()[:2], []string{"prod", "my-widget"}; !slices.Equal(got, want) {
+		t.Errorf("wrong fields\nwant: %q\ngot:  %q", want, got)
+	}
+
+	// Clearing one context keeps the other context's rows
+	prod.Clear()
+	if got := tbl.RowIndex("prod/uid-my-widget"); got != -1 {
+		t.Errorf("want prod row removed, got index %d", got)
+	}
+	if got := tbl.RowIndex("dev/uid-my-widget"); got != 0 {
+		t.Errorf("want dev row kept, got index %d", got)
+	}
+}
+
+func TestPrinterForContextConcurrent(t *testing.T) {
+	o := Options{ExtraColumns: []string{"SIZE=.spec.size"}}
+	extraColumns, err := parseExtraColumns(o.ExtraColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	printer := Printer{Table: table.New(), ExtraColumns: extraColumns}
+
+	prod, dev := printer.forContext(o, "prod"), printer.forContext(o, "dev")
+	// A JSONPath keeps state while evaluating ranges
+	if prod.ExtraColumns[0].Path == dev.ExtraColumns[0].Path {
+		t.Error("want each context to have its own JSONPath")
+	}
+
+	// Run with -race to catch any state shared between the contexts
+	var wg sync.WaitGroup
+	for _, p := range []Printer{prod, dev} {
+		p.fallback = &tableFallback{Clients: newTestFallbackClients(), GVR: gvrWidgets}
+		wg.Go(func() {
+			for i := range 50 {
+				if _, err := p.PrintObj(newTestWidget(fmt.Sprintf("widget-%d", i), 1), watch.Added); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	for _, id := range []string{"prod/uid-widget-49", "dev/uid-widget-49"} {
+		if printer.Table.RowIndex(id) == -1 {
+			t.Errorf("want row %q", id)
+		}
+	}
+}
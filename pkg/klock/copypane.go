@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+// copyFormat is a way of referring to an object that can be copied,
+// such as the object's name or a kubectl command for it.
+type copyFormat struct {
+	Name   string
+	Format func(obj *rowObject) string
+}
+
+// copyFormatsFor returns the copy formats that apply to the object.
+func copyFormatsFor(obj *rowObject) []copyFormat {
+	formats := []copyFormat{copyName}
+	if obj.Namespaced {
+		formats = append(formats, copyNamespacedName)
+	}
+	formats = append(formats, copyKindName, copyKubectlDescribe, copyKubectlGetYAML)
+	if obj.Is("", "Pod") {
+		formats = append(formats, copyKubectlLogs)
+	}
+	return formats
+}
+
+var copyName = copyFormat{
+	Name: "Name",
+	Format: func(obj *rowObject) string {
+		return obj.Object.GetName()
+	},
+}
+
+var copyNamespacedName = copyFormat{
+	Name: "Namespace/name",
+	Format: func(obj *rowObject) string {
+		return obj.Object.GetNamespace() + "/" + obj.Object.GetName()
+	},
+}
+
+var copyKindName = copyFormat{
+	Name: "Kind/name",
+	Format: func(obj *rowObject) string {
+		return kubectlKind(obj) + "/" + obj.Object.GetName()
+	},
+}
+
+var copyKubectlDescribe = copyFormat{
+	Name: "kubectl describe",
+	Format: func(obj *rowObject) string {
+		return kubectlCommand(obj, "describe", kubectlKind(obj), obj.Object.GetName())
+	},
+}
+
+var copyKubectlGetYAML = copyFormat{
+	Name: "kubectl get -o yaml",
+	Format: func(obj *rowObject) string {
+		return kubectlCommand(obj, "get", kubectlKind(obj), obj.Object.GetName(), "-o", "yaml")
+	},
+}
+
+var copyKubectlLogs = copyFormat{
+	Name: "kubectl logs",
+	Format: func(obj *rowObject) string {
+		return kubectlCommand(obj, "logs", obj.Object.GetName())
+	},
+}
+
+// kubectlKind returns the kind the same way as "kubectl get -o name" does,
+// such as "pod" or "deployment.apps".
+func kubectlKind(obj *rowObject) string {
+	kind := strings.ToLower(obj.GVK.Kind)
+	if obj.GVK.Group != "" {
+		kind += "." + obj.GVK.Group
+	}
+	return kind
+}
+
+func kubectlCommand(obj *rowObject, args ...string) string {
+	cmd := []string{"kubectl"}
+	if obj.Context != "" {
+		// Rows can be from other contexts than the current one
+		cmd = append(cmd, "--context", obj.Context)
+	}
+	if obj.Namespaced {
+		cmd = append(cmd, "-n", obj.Object.GetNamespace())
+	}
+	return strings.Join(append(cmd, args...), " ")
+}
+
+// copyPane is a menu of copy formats for the selected or marked rows.
+type copyPane struct {
+	styles    *table.Styles
+	keys      *KeyMap
+	clipboard *clipboard
+	objects   []*rowObject
+	formats   []copyFormat
+
+	cursor int
+	done   bool
+	result string
+	err    error
+}
+
+var _ table.Pane = &copyPane{}
+
+func openCopyPane(styles *table.Styles, keys *KeyMap, cb *clipboard, rows []table.Row) table.Pane {
+	var objects []*rowObject
+	for _, row := range rows {
+		if obj, ok := rowObjectOf(row); ok {
+			objects = append(objects, obj)
+		}
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+	return &copyPane{
+		styles:    styles,
+		keys:      keys,
+		clipboard: cb,
+		objects:   objects,
+		formats:   copyFormatsFor(objects[0]),
+	}
+}
+
+func (p *copyPane) Init() tea.Cmd {
+	return nil
+}
+
+func (p *copyPane) Close() {}
+
+func (p *copyPane) Update(msg tea.Msg) (table.Pane, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+	if p.done {
+		if key.Matches(keyMsg, p.keys.MenuSelect) {
+			p.done = false
+		}
+		return p, nil
+	}
+	switch {
+	case key.Matches(keyMsg, p.keys.MenuUp):
+		p.cursor = max(p.cursor-1, 0)
+	case key.Matches(keyMsg, p.keys.MenuDown):
+		p.cursor = min(p.cursor+1, len(p.formats)-1)
+	case key.Matches(keyMsg, p.keys.MenuSelect):
+		p.copy(p.formats[p.cursor])
+	}
+	return p, nil
+}
+
+func (p *copyPane) copy(format copyFormat) {
+	p.done = true
+	lines := make([]string, len(p.objects))
+	for i, obj := range p.objects {
+		lines[i] = format.Format(obj)
+	}
+	dest, err := p.clipboard.Copy(strings.Join(lines, "\n"))
+	p.err = err
+	if len(lines) == 1 {
+		p.result = fmt.Sprintf("Copied %q to %s", lines[0], dest)
+	} else {
+		p.result = fmt.Sprintf("Copied %d lines to %s", len(lines), dest)
+	}
+}
+
+func (p *copyPane) View(int, int) string {
+	var sb strings.Builder
+	sb.WriteString(p.styles.PaneTitle.Render("COPY:"))
+	if len(p.objects) > 1 {
+		fmt.Fprintf(&sb, " %d objects", len(p.objects))
+	}
+	sb.WriteByte('\n')
+	if p.done {
+		if p.err != nil {
+			sb.WriteString(p.styles.Error.Render(p.err.Error()))
+		} else {
+			sb.WriteString(p.result)
+		}
+		return sb.String()
+	}
+	for i, format := range p.formats {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		line := fmt.Sprintf("%-20s %s", format.Name, format.Format(p.objects[0]))
+		if i == p.cursor {
+			sb.WriteString(p.styles.Row.Selected.Render("> " + line))
+		} else {
+			sb.WriteString("  " + line)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+// Certificates are colored as warnings when they're this close to expiring,
+// which is when cert-manager by default renews 90 day certificates.
+const (
+	certificateExpiryWarning = 30 * 24 * time.Hour
+	certificateExpiryError   = 7 * 24 * time.Hour
+)
+
+var gvrCertificates = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+// countdownHeaderFor returns the header of the countdown column that is
+// added for the resource, or an empty string if there is none.
+func countdownHeaderFor(gvr schema.GroupVersionResource) string {
+	switch {
+	case gvr.Group == "batch" && gvr.Resource == "cronjobs":
+		return "NEXT SCHEDULE"
+	case gvr.GroupResource() == gvrCertificates.GroupResource():
+		return "EXPIRES"
+	default:
+		return ""
+	}
+}
+
+// countdownValue returns the cell of the countdown column, if any.
+func (p *Printer) countdownValue(row metav1.TableRow, obj map[string]any, deleted bool) any {
+	switch p.countdownHeader {
+	case "NEXT SCHEDULE":
+		schedule, ok := p.cronJobSchedule(row)
+		if !ok || deleted {
+			return "<none>"
+		}
+		return CountdownColumn(func() time.Time {
+			return schedule.Next(time.Now())
+		}, "passed", 0, 0)
+	case "EXPIRES":
+		notAfter, _, _ := unstructured.NestedString(obj, "status", "notAfter")
+		expires, err := time.Parse(time.RFC3339, notAfter)
+		if err != nil {
+			return "<none>"
+		}
+		if deleted {
+			return expires
+		}
+		return CountdownColumn(func() time.Time {
+			return expires
+		}, "expired", certificateExpiryWarning, certificateExpiryError)
+	default:
+		return nil
+	}
+}
+
+// cronJobSchedule parses the schedule of a CronJob from its table cells,
+// or returns false if it's suspended or has an invalid schedule.
+func (p *Printer) cronJobSchedule(row metav1.TableRow) (cron.Schedule, bool) {
+	var spec, timeZone string
+	for i, cell := range row.Cells {
+		if i >= len(p.colDefs) {
+			break
+		}
+		cellStr := fmt.Sprint(cell)
+		switch strings.ToLower(p.colDefs[i].Name) {
+		case "schedule":
+			spec = cellStr
+		case "timezone", "time zone":
+			timeZone = cellStr
+		case "suspend":
+			if cellStr == "True" || cellStr == "true" {
+				return nil, false
+			}
+		}
+	}
+	if timeZone != "" && timeZone != "<none>" && !strings.Contains(spec, "TZ=") {
+		spec = fmt.Sprintf("CRON_TZ=%s %s", timeZone, spec)
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, false
+	}
+	return schedule, true
+}
+
+// CountdownColumn renders a future time as a live countdown, e.g "in 5m",
+// that is colored as a warning or error when it gets closer than the given
+// durations. Times that have passed are shown with the passed text, e.g
+// "expired (5m ago)", and are colored as errors.
+// Zero durations disables the coloring.
+func CountdownColumn(next func() time.Time, passed string, warning, critical time.Duration) any {
+	return table.DynamicColumn{
+		Value: func() any {
+			t := next()
+			until := time.Until(t)
+			if until <= 0 {
+				return table.StyledColumn{
+					Value: table.AgoColumn{Value: passed, Time: t},
+					Style: StyleStatusError,
+				}
+			}
+			return table.StyledColumn{
+				Value: t,
+				Style: countdownStyle(until, warning, critical),
+			}
+		},
+	}
+}
+
+func countdownStyle(until, warning, critical time.Duration) lipgloss.Style {
+	switch {
+	case critical > 0 && until <= critical:
+		return StyleStatusError
+	case warning > 0 && until <= warning:
+		return StyleStatusWarning
+	default:
+		return StyleStatusDefault
+	}
+}
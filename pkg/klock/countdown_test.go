@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func TestCronJobSchedule(t *testing.T) {
+	p := &Printer{colDefs: []metav1.TableColumnDefinition{
+		{Name: "Name"},
+		{Name: "Schedule"},
+		{Name: "Timezone"},
+		{Name: "Suspend"},
+	}}
+	tests := []struct {
+		name  string
+		cells []any
+		want  time.Time
+		ok    bool
+	}{
+		{
+			name:  "hourly",
+			cells: []any{"my-cronjob", "0 * * * *", "<none>", "False"},
+			want:  time.Date(2026, 1, 2, 13, 0, 0, 0, time.Local),
+			ok:    true,
+		},
+		{
+			name:  "time zone",
+			cells: []any{"my-cronjob", "30 14 * * *", "UTC", "False"},
+			want:  time.Date(2026, 1, 2, 14, 30, 0, 0, time.UTC),
+			ok:    true,
+		},
+		{
+			name:  "suspended",
+			cells: []any{"my-cronjob", "0 * * * *", "<none>", "True"},
+		},
+		{
+			name:  "invalid",
+			cells: []any{"my-cronjob", "not a schedule", "<none>", "False"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, ok := p.cronJobSchedule(metav1.TableRow{Cells: test.cells})
+			if ok != test.ok {
+				t.Fatalf("want ok=%t, got %t", test.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			now := time.Date(2026, 1, 2, 12, 10, 0, 0, test.want.Location())
+			if got := schedule.Next(now); !got.Equal(test.want) {
+				t.Errorf("want next schedule %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCountdownColumn(t *testing.T) {
+	future := CountdownColumn(func() time.Time { return time.Now().Add(3*time.Hour + 30*time.Second) }, "passed", 0, 0)
+	past := CountdownColumn(func() time.Time { return time.Now().Add(-5 * time.Minute) }, "expired", 0, 0)
+	row := table.Row{Fields: []any{future, past}}
+	fields := row.PlainFields()
+	if got, want := fields[0], "in 3h"; got != want {
+		t.Errorf("wrong countdown\nwant: %q\ngot:  %q", want, got)
+	}
+	if got, want := fields[1], "expired (5m ago)"; got != want {
+		t.Errorf("wrong passed time\nwant: %q\ngot:  %q", want, got)
+	}
+}
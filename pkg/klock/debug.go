@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// debugStats are shown in the status line when using the --debug flag.
+type debugStats struct {
+	bytesReceived atomic.Int64
+	fullObjects   atomic.Bool
+}
+
+// WrapConfig makes all clients created from the config count the bytes
+// they receive.
+func (d *debugStats) WrapConfig(config *rest.Config) *rest.Config {
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &countingRoundTripper{next: rt, count: &d.bytesReceived}
+	})
+	return config
+}
+
+// SetFullObjects records whether the table rows include the full objects,
+// or only their metadata.
+func (d *debugStats) SetFullObjects(full bool) {
+	if d == nil {
+		return
+	}
+	d.fullObjects.Store(full)
+}
+
+func (d *debugStats) String() string {
+	includeObject := metav1.IncludeMetadata
+	if d.fullObjects.Load() {
+		includeObject = metav1.IncludeObject
+	}
+	return fmt.Sprintf("debug: %s received, includeObject=%s", formatBytes(d.bytesReceived.Load()), includeObject)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 3; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+// countingRoundTripper counts the bytes of the response bodies as they are
+// read, which for watches is as the events arrive.
+type countingRoundTripper struct {
+	next  http.RoundTripper
+	count *atomic.Int64
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(req)
+	if resp != nil && resp.Body != nil {
+		resp.Body = &countingReadCloser{ReadCloser: resp.Body, count: rt.count}
+	}
+	return resp, err
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	count *atomic.Int64
+}
+
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.count.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{bytes: 0, want: "0 B"},
+		{bytes: 1023, want: "1023 B"},
+		{bytes: 1536, want: "1.5 KiB"},
+		{bytes: 5 * 1024 * 1024, want: "5.0 MiB"},
+		{bytes: 3 * 1024 * 1024 * 1024, want: "3.0 GiB"},
+	}
+	for _, test := range tests {
+		if got := formatBytes(test.bytes); got != test.want {
+			t.Errorf("%d bytes: want %q, got %q", test.bytes, test.want, got)
+		}
+	}
+}
+
+func TestDebugStatsCountsBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, strings.Repeat("x", 2048))
+	}))
+	defer server.Close()
+
+	debug := &debugStats{}
+	config := debug.WrapConfig(&rest.Config{Host: server.URL})
+	client, err := rest.HTTPClientFor(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	debug.SetFullObjects(true)
+	if got, want := debug.String(), "debug: 2.0 KiB received, includeObject=Object"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
@@ -22,6 +22,7 @@
 package klock
 
 import (
+	"errors"
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,9 +43,13 @@ var (
 	_ metav1beta1.Table = metav1.Table{}
 )
 
+// errNotTable is returned by [decodeIntoTable] when the server didn't
+// respond with a table, such as for some aggregated APIs.
+var errNotTable = errors.New("attempt to decode non-Table object")
+
 func decodeIntoTable(obj runtime.Object) (*metav1.Table, error) {
 	if !recognizedTableVersions[obj.GetObjectKind().GroupVersionKind()] {
-		return nil, fmt.Errorf("attempt to decode non-Table object")
+		return nil, errNotTable
 	}
 
 	unstr, ok := obj.(*unstructured.Unstructured)
@@ -0,0 +1,287 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+// eventsPane lists the Kubernetes Events of a single object, using
+// a secondary watch on the "involvedObject.uid" field.
+type eventsPane struct {
+	styles    *table.Styles
+	client    kubernetes.Interface
+	namespace string
+	uid       string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	ch     chan eventsPaneMsg
+
+	events map[string]*corev1.Event
+	err    error
+}
+
+type eventsPaneMsg struct {
+	pane  *eventsPane
+	event watch.Event
+	err   error
+}
+
+var _ table.Pane = &eventsPane{}
+
+func openEventsPane(configFlags *genericclioptions.ConfigFlags, styles *table.Styles, row table.Row) table.Pane {
+	obj, ok := rowObjectOf(row)
+	if !ok || obj.Is("", "Event") || obj.Is("events.k8s.io", "Event") {
+		return nil
+	}
+	client, err := newClientset(obj.configFlagsOr(configFlags))
+	if err != nil {
+		return &eventsPane{styles: styles, err: err}
+	}
+	return newEventsPane(client, styles, obj)
+}
+
+func newEventsPane(client kubernetes.Interface, styles *table.Styles, obj *rowObject) *eventsPane {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &eventsPane{
+		styles: styles,
+		client: client,
+		// Events of cluster-scoped objects are usually found in the
+		// "default" namespace, so we look in all namespaces for them.
+		namespace: obj.Object.GetNamespace(),
+		uid:       string(obj.Object.GetUID()),
+
+		ctx:    ctx,
+		cancel: cancel,
+		ch:     make(chan eventsPaneMsg),
+
+		events: map[string]*corev1.Event{},
+	}
+}
+
+func (p *eventsPane) Init() tea.Cmd {
+	if p.client == nil {
+		return nil
+	}
+	go p.watchLoop()
+	return p.waitForMsg()
+}
+
+func (p *eventsPane) Close() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+func (p *eventsPane) waitForMsg() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case msg := <-p.ch:
+			return msg
+		case <-p.ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (p *eventsPane) send(msg eventsPaneMsg) bool {
+	msg.pane = p
+	select {
+	case p.ch <- msg:
+		return true
+	case <-p.ctx.Done():
+		return false
+	}
+}
+
+func (p *eventsPane) watchLoop() {
+	opts := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", p.uid).String(),
+	}
+	for {
+		// Watching without a resourceVersion gives us "ADDED" events
+		// for all existing events first.
+		w, err := p.client.CoreV1().Events(p.namespace).Watch(p.ctx, opts)
+		if err != nil {
+			if !p.send(eventsPaneMsg{err: fmt.Errorf("watch events: %w", err)}) {
+				return
+			}
+		} else {
+			for event := range w.ResultChan() {
+				if !p.send(eventsPaneMsg{event: event}) {
+					w.Stop()
+					return
+				}
+			}
+			w.Stop()
+		}
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func (p *eventsPane) Update(msg tea.Msg) (table.Pane, tea.Cmd) {
+	msg2, ok := msg.(eventsPaneMsg)
+	if !ok || msg2.pane != p {
+		return p, nil
+	}
+	p.err = msg2.err
+	switch msg2.event.Type {
+	case watch.Added, watch.Modified:
+		if event, ok := msg2.event.Object.(*corev1.Event); ok {
+			p.events[string(event.UID)] = event
+		}
+	case watch.Deleted:
+		if event, ok := msg2.event.Object.(*corev1.Event); ok {
+			delete(p.events, string(event.UID))
+		}
+	case watch.Error:
+		p.err = apierrors.FromObject(msg2.event.Object)
+	}
+	return p, p.waitForMsg()
+}
+
+func (p *eventsPane) View(width, height int) string {
+	var sb strings.Builder
+	sb.WriteString(p.styles.PaneTitle.Render("EVENTS:"))
+	if p.err != nil {
+		sb.WriteByte(' ')
+		sb.WriteString(p.styles.Error.Render(p.err.Error()))
+	}
+	sb.WriteByte('\n')
+	if len(p.events) == 0 {
+		sb.WriteString(p.styles.NoneFound.UnsetString().Render("No events found"))
+		return sb.String()
+	}
+
+	events := p.sortedEvents()
+	if height > 2 && len(events) > height-2 {
+		// Show the latest events
+		events = events[len(events)-(height-2):]
+	}
+
+	lines := make([][]string, 0, len(events)+1)
+	lines = append(lines, []string{"LAST SEEN", "TYPE", "REASON", "MESSAGE"})
+	for _, event := range events {
+		lastSeen := duration.HumanDuration(time.Since(eventTime(event)))
+		if event.Count > 1 {
+			lastSeen = fmt.Sprintf("%s (x%d)", lastSeen, event.Count)
+		}
+		typeStyle := StyleStatusDefault
+		if event.Type == corev1.EventTypeWarning {
+			typeStyle = StyleStatusWarning
+		}
+		lines = append(lines, []string{
+			lastSeen,
+			typeStyle.Render(event.Type),
+			StatusStyle(event.Reason).Render(event.Reason),
+			strings.ReplaceAll(strings.TrimSpace(event.Message), "\n", " "),
+		})
+	}
+	writeAlignedLines(&sb, lines, width, p.styles.Header)
+	return sb.String()
+}
+
+func (p *eventsPane) sortedEvents() []*corev1.Event {
+	events := make([]*corev1.Event, 0, len(p.events))
+	for _, event := range p.events {
+		events = append(events, event)
+	}
+	slices.SortFunc(events, func(a, b *corev1.Event) int {
+		return cmp.Or(
+			eventTime(a).Compare(eventTime(b)),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	return events
+}
+
+// eventTime returns the time an event was last seen, using the same
+// fallbacks as "kubectl events".
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// writeAlignedLines writes the cells in aligned columns, where the first
+// line is treated as a header. Lines are truncated to fit the width.
+func writeAlignedLines(sb *strings.Builder, lines [][]string, width int, headerStyle lipgloss.Style) {
+	const cellSpacing = 3
+	var widths []int
+	for _, line := range lines {
+		for i, cell := range line {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+	for lineIndex, line := range lines {
+		if lineIndex > 0 {
+			sb.WriteByte('\n')
+		}
+		var lineSB strings.Builder
+		for i, cell := range line {
+			lineSB.WriteString(cell)
+			if i < len(line)-1 {
+				lineSB.WriteString(strings.Repeat(" ", widths[i]-lipgloss.Width(cell)+cellSpacing))
+			}
+		}
+		text := lineSB.String()
+		if width > 0 {
+			text = truncate.StringWithTail(text, uint(width), "…")
+		}
+		if lineIndex == 0 {
+			text = headerStyle.Render(text)
+		}
+		sb.WriteString(text)
+	}
+}
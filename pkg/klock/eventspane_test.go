@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func TestEventsPaneUpdate(t *testing.T) {
+	pod := &unstructured.Unstructured{}
+	pod.SetNamespace("default")
+	pod.SetName("my-pod")
+	pod.SetUID("pod-uid")
+
+	styles := table.DefaultStyles
+	p := newEventsPane(fake.NewClientset(), &styles, &rowObject{Object: pod})
+	defer p.Close()
+
+	newEvent := func(uid, reason string, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:    metav1.ObjectMeta{UID: apitypes.UID("event-" + uid), Name: uid},
+			Type:          corev1.EventTypeWarning,
+			Reason:        reason,
+			Message:       "message for " + reason,
+			LastTimestamp: metav1.NewTime(lastSeen),
+		}
+	}
+	now := time.Now()
+	p.Update(eventsPaneMsg{pane: p, event: watch.Event{Type: watch.Added, Object: newEvent("b", "BackOff", now)}})
+	p.Update(eventsPaneMsg{pane: p, event: watch.Event{Type: watch.Added, Object: newEvent("a", "Pulled", now.Add(-time.Minute))}})
+	p.Update(eventsPaneMsg{pane: p, event: watch.Event{Type: watch.Added, Object: newEvent("c", "Unhealthy", now)}})
+	p.Update(eventsPaneMsg{pane: p, event: watch.Event{Type: watch.Deleted, Object: newEvent("c", "Unhealthy", now)}})
+	// Messages from other panes should be ignored
+	p.Update(eventsPaneMsg{pane: &eventsPane{}, event: watch.Event{Type: watch.Added, Object: newEvent("d", "Killing", now)}})
+
+	view := p.View(200, 20)
+	for _, want := range []string{"EVENTS:", "Pulled", "BackOff"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want view to contain %q, got:\n%s", want, view)
+		}
+	}
+	for _, unwanted := range []string{"Unhealthy", "Killing"} {
+		if strings.Contains(view, unwanted) {
+			t.Errorf("want view to not contain %q, got:\n%s", unwanted, view)
+		}
+	}
+	if strings.Index(view, "Pulled") > strings.Index(view, "BackOff") {
+		t.Errorf("want events sorted by last seen, got:\n%s", view)
+	}
+}
+
+func TestEventsPaneWatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		obj           *rowObject
+		wantNamespace string
+	}{
+		{
+			name:          "namespaced",
+			obj:           newTestRowObject(gvrPods, "Pod", "default", "my-pod"),
+			wantNamespace: "default",
+		},
+		{
+			name: "cluster-scoped in all namespaces",
+			obj:  newTestRowObject(gvrNodes, "Node", "", "my-node"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.obj.Object.SetUID("obj-uid")
+			client := fake.NewClientset()
+			watches := make(chan k8stesting.WatchActionImpl, 1)
+			client.PrependWatchReactor("events", func(action k8stesting.Action) (bool, watch.Interface, error) {
+				watches <- action.(k8stesting.WatchActionImpl)
+				w := watch.NewFakeWithChanSize(1, false)
+				w.Add(&corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{UID: "event-uid", Name: "my-event"},
+					Reason:     "Scheduled",
+				})
+				return true, w, nil
+			})
+
+			styles := table.DefaultStyles
+			p := newEventsPane(client, &styles, tc.obj)
+			defer p.Close()
+			cmd := p.Init()
+
+			select {
+			case action := <-watches:
+				if got := action.GetNamespace(); got != tc.wantNamespace {
+					t.Errorf("want namespace %q, got %q", tc.wantNamespace, got)
+				}
+				if got, want := action.GetWatchRestrictions().Fields.String(), "involvedObject.uid=obj-uid"; got != want {
+					t.Errorf("want field selector %q, got %q", want, got)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for events watch")
+			}
+
+			p.Update(cmd())
+			if view := p.View(200, 20); !strings.Contains(view, "Scheduled") {
+				t.Errorf("want watched event in view, got:\n%s", view)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+type exportDestination int
+
+const (
+	exportToFile exportDestination = iota
+	exportToClipboard
+)
+
+// exportPane lets the user pick a format and a destination for a snapshot
+// of the rows, and then writes it.
+type exportPane struct {
+	styles    *table.Styles
+	keys      *KeyMap
+	clipboard *clipboard
+	headers   []string
+	rows      []table.Row
+	format    table.ExportFormat
+
+	cursor exportDestination
+	done   bool
+	result string
+	err    error
+}
+
+var _ table.Pane = &exportPane{}
+
+func openExportPane(styles *table.Styles, keys *KeyMap, cb *clipboard, format table.ExportFormat, headers []string, rows []table.Row) table.Pane {
+	return &exportPane{
+		styles:    styles,
+		keys:      keys,
+		clipboard: cb,
+		headers:   headers,
+		rows:      rows,
+		format:    format,
+	}
+}
+
+func (p *exportPane) Init() tea.Cmd {
+	return nil
+}
+
+func (p *exportPane) Close() {}
+
+func (p *exportPane) Update(msg tea.Msg) (table.Pane, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+	if p.done {
+		if key.Matches(keyMsg, p.keys.MenuSelect) {
+			p.done = false
+		}
+		return p, nil
+	}
+	switch {
+	case key.Matches(keyMsg, p.keys.MenuUp):
+		p.cursor = exportToFile
+	case key.Matches(keyMsg, p.keys.MenuDown):
+		p.cursor = exportToClipboard
+	case key.Matches(keyMsg, p.keys.ExportNextFormat):
+		index := slices.Index(table.ExportFormats, p.format)
+		p.format = table.ExportFormats[(index+1)%len(table.ExportFormats)]
+	case key.Matches(keyMsg, p.keys.MenuSelect):
+		p.export()
+	}
+	return p, nil
+}
+
+func (p *exportPane) export() {
+	p.done = true
+	p.result = ""
+	p.err = nil
+	var sb strings.Builder
+	if err := table.Export(&sb, p.format, p.headers, p.rows); err != nil {
+		p.err = fmt.Errorf("export: %w", err)
+		return
+	}
+	switch p.cursor {
+	case exportToClipboard:
+		dest, err := p.clipboard.Copy(sb.String())
+		if err != nil {
+			p.err = err
+			return
+		}
+		p.result = fmt.Sprintf("Copied %d rows as %s to %s", len(p.rows), p.format, dest)
+	default:
+		fileName := fmt.Sprintf("klock-%s%s", time.Now().Format("20060102-150405"), p.format.FileExtension())
+		if err := os.WriteFile(fileName, []byte(sb.String()), 0o644); err != nil {
+			p.err = fmt.Errorf("export: %w", err)
+			return
+		}
+		p.result = fmt.Sprintf("Wrote %d rows as %s to %s", len(p.rows), p.format, fileName)
+	}
+}
+
+func (p *exportPane) View(int, int) string {
+	var sb strings.Builder
+	sb.WriteString(p.styles.PaneTitle.Render("EXPORT:"))
+	fmt.Fprintf(&sb, " %d rows as %s", len(p.rows), p.format)
+	sb.WriteByte('\n')
+	if p.done {
+		if p.err != nil {
+			sb.WriteString(p.styles.Error.Render(p.err.Error()))
+		} else {
+			sb.WriteString(p.result)
+		}
+		return sb.String()
+	}
+	for i, item := range []string{"Save to file", "Copy to clipboard"} {
+		if exportDestination(i) == p.cursor {
+			sb.WriteString(p.styles.Row.Selected.Render("> " + item))
+		} else {
+			sb.WriteString("  " + item)
+		}
+		sb.WriteByte('\n')
+	}
+	help := p.keys.ExportNextFormat.Help()
+	sb.WriteString(p.styles.Toggles.Render(fmt.Sprintf("%s: %s", help.Key, help.Desc)))
+	return sb.String()
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func TestExportPaneToClipboard(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+	var buf bytes.Buffer
+
+	styles := table.DefaultStyles
+	rows := []table.Row{{Fields: []any{"my-pod", "Running"}}}
+	p := openExportPane(&styles, &DefaultKeyMap, &clipboard{Output: &buf}, table.ExportText, []string{"NAME", "STATUS"}, rows)
+
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if got, want := p.View(80, 10), "Copied 1 rows as csv to the clipboard"; !strings.Contains(got, want) {
+		t.Errorf("want view to contain %q, got:\n%s", want, got)
+	}
+	if got, want := buf.String(), wantOSC52("NAME,STATUS\nmy-pod,Running\n"); got != want {
+		t.Errorf("wrong OSC 52 sequence\nwant: %q\ngot:  %q", want, got)
+	}
+}
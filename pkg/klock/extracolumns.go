@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/kubectl/pkg/cmd/get"
+
+	"github.com/applejag/kubectl-klock/internal/util"
+)
+
+// extraColumn is a column computed from a JSONPath expression on the object,
+// added via the --extra-column flag.
+type extraColumn struct {
+	Header string
+	Path   *jsonpath.JSONPath
+	// Duration is set when human durations (e.g "5m" or "in 5m") should be
+	// shown as a live age or countdown.
+	Duration bool
+}
+
+// extraColumnDurationSuffix is added to a column spec to opt in to
+// [extraColumn.Duration], as values such as "500m" may just as well be
+// quantities.
+const extraColumnDurationSuffix = ":duration"
+
+// parseExtraColumns parses NAME=JSONPATH[:duration] column specs.
+// The JSONPath can be given with or without the surrounding braces, same as
+// for "kubectl get -o custom-columns", e.g "NODE=.spec.nodeName".
+func parseExtraColumns(specs []string) ([]extraColumn, error) {
+	columns := make([]extraColumn, 0, len(specs))
+	for _, spec := range specs {
+		name, expr, ok := strings.Cut(spec, "=")
+		if !ok || name == "" || expr == "" {
+			return nil, fmt.Errorf("invalid extra column %q, want NAME=JSONPATH", spec)
+		}
+		expr, duration := strings.CutSuffix(expr, extraColumnDurationSuffix)
+		relaxed, err := get.RelaxedJSONPathExpression(expr)
+		if err != nil {
+			return nil, fmt.Errorf("extra column %q: %w", name, err)
+		}
+		path := jsonpath.New(name).AllowMissingKeys(true)
+		if err := path.Parse(relaxed); err != nil {
+			return nil, fmt.Errorf("extra column %q: %w", name, err)
+		}
+		columns = append(columns, extraColumn{
+			Header:   strings.ToUpper(name),
+			Path:     path,
+			Duration: duration,
+		})
+	}
+	return columns, nil
+}
+
+// Value evaluates the column's JSONPath on the object. Timestamps
+// (e.g "2026-01-02T12:00:00Z") are turned into a [time.Time], so they're
+// rendered as a live age or countdown like the AGE column. So are human
+// durations (e.g "5m" or "in 5m"), but only if [extraColumn.Duration] is set.
+func (c extraColumn) Value(obj map[string]any) any {
+	results, err := c.Path.FindResults(obj)
+	if err != nil {
+		return fmt.Sprintf("<error: %s>", err)
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			if value.IsValid() && value.CanInterface() {
+				values = append(values, fmt.Sprint(value.Interface()))
+			}
+		}
+	}
+	if len(values) == 0 {
+		return "<none>"
+	}
+	if len(values) > 1 {
+		return strings.Join(values, ",")
+	}
+	str := values[0]
+	if t, err := time.Parse(time.RFC3339, str); err == nil {
+		return t
+	}
+	if !c.Duration {
+		return str
+	}
+	if t, ok := util.ParseHumanTime(str); ok {
+		return t
+	}
+	return str
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseExtraColumns(t *testing.T) {
+	tests := []struct {
+		spec       string
+		wantHeader string
+		wantErr    bool
+	}{
+		{spec: "node=.spec.nodeName", wantHeader: "NODE"},
+		{spec: "IP={.status.podIP}", wantHeader: "IP"},
+		{spec: "missing-path", wantErr: true},
+		{spec: "=.spec.nodeName", wantErr: true},
+		{spec: "bad=.spec[", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			columns, err := parseExtraColumns([]string{test.spec})
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("want error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := columns[0].Header; got != test.wantHeader {
+				t.Errorf("want header %q, got %q", test.wantHeader, got)
+			}
+		})
+	}
+}
+
+func TestExtraColumnValue(t *testing.T) {
+	obj := map[string]any{
+		"spec": map[string]any{
+			"nodeName": "node-1",
+			"containers": []any{
+				map[string]any{"name": "app", "ports": []any{map[string]any{"containerPort": int64(80)}}},
+				map[string]any{"name": "sidecar"},
+			},
+		},
+		"status": map[string]any{
+			"startTime": "2026-01-02T12:00:00Z",
+			"expiresIn": "in 3d",
+			"cpu":       "500m",
+			"memory":    "1Gi",
+		},
+	}
+	tests := []struct {
+		spec string
+		want any
+	}{
+		{spec: "NODE=.spec.nodeName", want: "node-1"},
+		{spec: "PORT=.spec.containers[0].ports[0].containerPort", want: "80"},
+		{spec: "CONTAINERS=.spec.containers[*].name", want: "app,sidecar"},
+		{spec: "IP=.status.podIP", want: "<none>"},
+		{spec: "STARTED=.status.startTime", want: time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)},
+		{spec: "CPU=.status.cpu", want: "500m"},
+		{spec: "MEM=.status.memory", want: "1Gi"},
+		{spec: "EXPIRES=.status.expiresIn", want: "in 3d"},
+		{spec: "MEM=.status.memory:duration", want: "1Gi"},
+	}
+
+	expires, err := parseExtraColumns([]string{"EXPIRES=.status.expiresIn:duration"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := expires[0].Value(obj).(time.Time); !ok || time.Until(got) < 71*time.Hour {
+		t.Errorf("want time 3 days in the future, got %#v", got)
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			columns, err := parseExtraColumns([]string{test.spec})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := columns[0].Value(obj)
+			if wantTime, ok := test.want.(time.Time); ok {
+				if gotTime, ok := got.(time.Time); !ok || !gotTime.Equal(wantTime) {
+					t.Errorf("want %v, got %#v", wantTime, got)
+				}
+				return
+			}
+			if got != test.want {
+				t.Errorf("want %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseExtraColumnsDuration(t *testing.T) {
+	columns, err := parseExtraColumns([]string{"AGE=.status.age:duration", "CPU=.status.cpu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !columns[0].Duration {
+		t.Error("want duration column with :duration suffix")
+	}
+	if columns[1].Duration {
+		t.Error("want no duration column without :duration suffix")
+	}
+	obj := map[string]any{"status": map[string]any{"age": "5m"}}
+	if got, ok := columns[0].Value(obj).(time.Time); !ok || time.Since(got) < 5*time.Minute-time.Second {
+		t.Errorf("want time 5 minutes ago, got %#v", got)
+	}
+}
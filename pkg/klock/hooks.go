@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+// hookQueueSize is the number of events that can wait for a free hook
+// worker before new events are dropped.
+const hookQueueSize = 100
+
+// hookEventTypes are the watch event types that hooks can be run for.
+var hookEventTypes = []watch.EventType{watch.Added, watch.Modified, watch.Deleted}
+
+// Hooks runs user commands for watch events. The commands are run
+// asynchronously by a limited number of workers, so slow hooks never
+// block the watch.
+//
+// Events are only queued after [Hooks.Arm] is called, so that the initial
+// list of resources does not run the hooks for every single row.
+type Hooks struct {
+	Commands   []string
+	EventTypes []watch.EventType
+	// Filter, if set, only runs the hooks for rows that contain this text.
+	Filter  string
+	Timeout time.Duration
+	// OnError is called when a hook fails, or when an event is dropped.
+	OnError func(err error)
+
+	queue chan hookEvent
+	armed atomic.Bool
+}
+
+type hookEvent struct {
+	eventType watch.EventType
+	headers   []string
+	row       table.Row
+	object    []byte
+}
+
+func (o Options) newHooks() (*Hooks, error) {
+	if len(o.OnEvent) == 0 {
+		return nil, nil
+	}
+	h := &Hooks{
+		Commands: o.OnEvent,
+		Filter:   o.OnEventFilter,
+		Timeout:  o.OnEventTimeout,
+		queue:    make(chan hookEvent, hookQueueSize),
+	}
+	if o.OnEventConcurrency < 1 {
+		return nil, fmt.Errorf("on-event concurrency must be at least 1, got %d", o.OnEventConcurrency)
+	}
+	for _, name := range o.OnEventTypes {
+		index := slices.IndexFunc(hookEventTypes, func(t watch.EventType) bool {
+			return strings.EqualFold(string(t), name)
+		})
+		if index == -1 {
+			return nil, fmt.Errorf("unknown on-event type: %q, allowed types are: %s", name, hookEventTypes)
+		}
+		h.EventTypes = append(h.EventTypes, hookEventTypes[index])
+	}
+	return h, nil
+}
+
+// Start starts the workers that run the hooks, until the context is
+// cancelled.
+func (h *Hooks) Start(ctx context.Context, workers int) {
+	if h == nil {
+		return
+	}
+	for range workers {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event := <-h.queue:
+					h.run(ctx, event)
+				}
+			}
+		}()
+	}
+}
+
+// forContext returns hooks that share the commands and the workers with h,
+// but that are armed separately, as each context syncs on its own.
+func (h *Hooks) forContext() *Hooks {
+	if h == nil {
+		return nil
+	}
+	return &Hooks{
+		Commands:   h.Commands,
+		EventTypes: h.EventTypes,
+		Filter:     h.Filter,
+		Timeout:    h.Timeout,
+		OnError:    h.OnError,
+		queue:      h.queue,
+	}
+}
+
+// Arm enables the hooks. Any events seen before this are ignored.
+func (h *Hooks) Arm() {
+	if h == nil {
+		return
+	}
+	h.armed.Store(true)
+}
+
+// Reset disables the hooks until [Hooks.Arm] is called again, such as when
+// the watch is restarted.
+func (h *Hooks) Reset() {
+	if h == nil {
+		return
+	}
+	h.armed.Store(false)
+}
+
+// Observe queues the hooks to run for the row, if the event matches.
+// It never blocks, and drops the event if the queue is full.
+func (h *Hooks) Observe(row table.Row, eventType watch.EventType, headers []string) {
+	if h == nil || !h.armed.Load() {
+		return
+	}
+	if len(h.EventTypes) > 0 && !slices.Contains(h.EventTypes, eventType) {
+		return
+	}
+	fields := row.PlainFields()
+	if h.Filter != "" && !slices.ContainsFunc(fields, func(field string) bool {
+		return strings.Contains(field, h.Filter)
+	}) {
+		return
+	}
+	event := hookEvent{
+		eventType: eventType,
+		headers:   headers,
+		row:       row,
+	}
+	if obj, ok := rowObjectOf(row); ok {
+		object, err := json.Marshal(obj.Object.Object)
+		if err != nil {
+			h.onError(fmt.Errorf("on-event hook: %w", err))
+			return
+		}
+		event.object = object
+	}
+	select {
+	case h.queue <- event:
+	default:
+		h.onError(fmt.Errorf("on-event hook: too many queued events, dropped %s event", eventType))
+	}
+}
+
+func (h *Hooks) run(ctx context.Context, event hookEvent) {
+	env := append(os.Environ(),
+		"KLOCK_EVENT_TYPE="+string(event.eventType),
+		"KLOCK_STATE="+event.row.State,
+		"KLOCK_HEADERS="+strings.Join(event.headers, "\t"),
+		"KLOCK_ROW="+strings.Join(event.row.PlainFields(), "\t"),
+	)
+	if obj, ok := rowObjectOf(event.row); ok {
+		env = append(env,
+			"KLOCK_KIND="+obj.GVK.Kind,
+			"KLOCK_NAMESPACE="+obj.Object.GetNamespace(),
+			"KLOCK_NAME="+obj.Object.GetName(),
+		)
+	}
+	for _, command := range h.Commands {
+		if err := h.runCommand(ctx, command, env, event.object); err != nil {
+			h.onError(fmt.Errorf("on-event hook %q: %w", command, err))
+		}
+	}
+}
+
+func (h *Hooks) runCommand(ctx context.Context, command string, env []string, stdin []byte) error {
+	if h.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.Timeout)
+		defer cancel()
+	}
+	cmd := shellCommand(ctx, command)
+	cmd.Env = env
+	cmd.Stdin = bytes.NewReader(stdin)
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", h.Timeout)
+	}
+	if err != nil && len(out) > 0 {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(out))
+	}
+	return err
+}
+
+func (h *Hooks) onError(err error) {
+	if h.OnError != nil {
+		h.OnError(err)
+	}
+}
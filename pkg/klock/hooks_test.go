@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func TestNewHooks(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{
+			name: "no hooks",
+			opts: Options{},
+		},
+		{
+			name: "event types",
+			opts: Options{OnEvent: []string{"true"}, OnEventTypes: []string{"added", "DELETED"}, OnEventConcurrency: 1},
+		},
+		{
+			name:    "unknown event type",
+			opts:    Options{OnEvent: []string{"true"}, OnEventTypes: []string{"bookmark"}, OnEventConcurrency: 1},
+			wantErr: true,
+		},
+		{
+			name:    "no concurrency",
+			opts:    Options{OnEvent: []string{"true"}},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.opts.newHooks()
+			if (err != nil) != test.wantErr {
+				t.Errorf("want error: %t, got: %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func newTestHookRow(name string) table.Row {
+	return table.Row{
+		ID:     name,
+		Fields: []any{name, "Running"},
+		State:  "Running",
+		Object: newTestRowObject(gvrPods, "Pod", "default", name),
+	}
+}
+
+func TestHooksObserve(t *testing.T) {
+	h := &Hooks{
+		EventTypes: []watch.EventType{watch.Deleted},
+		Filter:     "pod-a",
+		queue:      make(chan hookEvent, 1),
+	}
+	var errs []error
+	h.OnError = func(err error) { errs = append(errs, err) }
+	h.Arm()
+
+	h.Observe(newTestHookRow("pod-a"), watch.Added, nil)
+	h.Observe(newTestHookRow("pod-b"), watch.Deleted, nil)
+	if len(h.queue) != 0 {
+		t.Fatalf("want filtered events to not be queued, got %d", len(h.queue))
+	}
+
+	h.Observe(newTestHookRow("pod-a"), watch.Deleted, nil)
+	h.Observe(newTestHookRow("pod-a"), watch.Deleted, nil)
+	if len(h.queue) != 1 {
+		t.Fatalf("want 1 queued event, got %d", len(h.queue))
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "dropped DELETED event") {
+		t.Errorf("want error about dropped event, got: %v", errs)
+	}
+}
+
+func TestHooksInitialList(t *testing.T) {
+	hooks := &Hooks{queue: make(chan hookEvent, hookQueueSize)}
+	var errs []error
+	hooks.OnError = func(err error) { errs = append(errs, err) }
+	p := Printer{
+		Table:    table.New(),
+		Hooks:    hooks.forContext(),
+		fallback: &tableFallback{Clients: newTestFallbackClients(), GVR: gvrWidgets},
+	}
+
+	// More rows than fit in the queue
+	for i := range hookQueueSize + 50 {
+		if _, err := p.PrintObj(newTestWidget(fmt.Sprintf("widget-%d", i), 1), watch.Added); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(hooks.queue) != 0 || len(errs) != 0 {
+		t.Fatalf("want no hooks for the initial list, got %d queued events and errors: %v", len(hooks.queue), errs)
+	}
+
+	p.Hooks.Arm()
+	if _, err := p.PrintObj(newTestWidget("widget-0", 2), watch.Modified); err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks.queue) != 1 {
+		t.Fatalf("want 1 queued event once synced, got %d", len(hooks.queue))
+	}
+
+	// Restarting the watch lists all the rows again
+	p.Clear()
+	if _, err := p.PrintObj(newTestWidget("widget-0", 2), watch.Added); err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks.queue) != 1 {
+		t.Errorf("want no hooks after restarting the watch, got %d queued events", len(hooks.queue))
+	}
+}
+
+func TestHooksRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a POSIX shell")
+	}
+	dir := t.TempDir()
+	errs := make(chan error, 1)
+	h := &Hooks{
+		Commands: []string{
+			`cat > ` + filepath.Join(dir, "stdin.json"),
+			`echo "$KLOCK_EVENT_TYPE $KLOCK_NAMESPACE/$KLOCK_NAME $KLOCK_ROW" > ` + filepath.Join(dir, "env.txt"),
+			`sleep 5`,
+		},
+		Timeout: 100 * time.Millisecond,
+		OnError: func(err error) { errs <- err },
+		queue:   make(chan hookEvent, 1),
+	}
+	h.Arm()
+	h.Observe(newTestHookRow("pod-a"), watch.Modified, []string{"NAME", "STATUS"})
+	h.run(context.Background(), <-h.queue)
+
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "timed out") {
+			t.Errorf("want timeout error, got: %v", err)
+		}
+	default:
+		t.Error("want timeout error from slow hook")
+	}
+
+	stdin, err := os.ReadFile(filepath.Join(dir, "stdin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(stdin), `"name":"pod-a"`) {
+		t.Errorf("want object JSON on stdin, got: %s", stdin)
+	}
+	env, err := os.ReadFile(filepath.Join(dir, "env.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(env), "MODIFIED default/pod-a pod-a\tRunning\n"; got != want {
+		t.Errorf("wrong environment\nwant: %q\ngot:  %q", want, got)
+	}
+}
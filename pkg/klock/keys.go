@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines the keybindings for the Kubernetes specific panes,
+// on top of the ones found in [table.KeyMap].
+type KeyMap struct {
+	// Keybindings for opening panes about the selected row.
+	ShowEvents  key.Binding
+	ShowLogs    key.Binding
+	ShowActions key.Binding
+
+	// Keybindings for the selected or marked rows.
+	Copy key.Binding
+
+	// Keybindings used inside menus, such as the actions pane.
+	MenuUp     key.Binding
+	MenuDown   key.Binding
+	MenuSelect key.Binding
+	MenuCancel key.Binding
+	Confirm    key.Binding
+
+	// Keybindings used inside the export pane.
+	ExportNextFormat key.Binding
+
+	// Keybindings used inside the logs pane.
+	LogsNextContainer  key.Binding
+	LogsToggleFollow   key.Binding
+	LogsTogglePrevious key.Binding
+	LogsSearch         key.Binding
+	LogsNextMatch      key.Binding
+	LogsPrevMatch      key.Binding
+	LogsScrollUp       key.Binding
+	LogsScrollDown     key.Binding
+	LogsPageUp         key.Binding
+	LogsPageDown       key.Binding
+	LogsGoToStart      key.Binding
+	LogsGoToEnd        key.Binding
+}
+
+// DefaultKeyMap is a default set of keybindings.
+var DefaultKeyMap = KeyMap{
+	ShowEvents: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "show events"),
+	),
+	ShowLogs: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "show pod logs"),
+	),
+	ShowActions: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "show actions"),
+	),
+
+	Copy: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy name or command"),
+	),
+
+	MenuUp: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "move up"),
+	),
+	MenuDown: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "move down"),
+	),
+	MenuSelect: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "select"),
+	),
+	MenuCancel: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "cancel"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("y", "Y"),
+		key.WithHelp("y", "confirm"),
+	),
+
+	ExportNextFormat: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "change format"),
+	),
+
+	LogsNextContainer: key.NewBinding(
+		key.WithKeys("tab", "c"),
+		key.WithHelp("tab/c", "next container"),
+	),
+	LogsToggleFollow: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "toggle follow"),
+	),
+	LogsTogglePrevious: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "toggle previous container"),
+	),
+	LogsSearch: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search"),
+	),
+	LogsNextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	LogsPrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous match"),
+	),
+	LogsScrollUp: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "scroll up"),
+	),
+	LogsScrollDown: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "scroll down"),
+	),
+	LogsPageUp: key.NewBinding(
+		key.WithKeys("pgup", "left", "h"),
+		key.WithHelp("←/h/pgup", "page up"),
+	),
+	LogsPageDown: key.NewBinding(
+		key.WithKeys("pgdown", "right", "l"),
+		key.WithHelp("→/l/pgdn", "page down"),
+	),
+	LogsGoToStart: key.NewBinding(
+		key.WithKeys("home", "g"),
+		key.WithHelp("g/home", "go to start"),
+	),
+	LogsGoToEnd: key.NewBinding(
+		key.WithKeys("end", "G"),
+		key.WithHelp("G/end", "go to end"),
+	),
+}
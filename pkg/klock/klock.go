@@ -21,6 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -31,6 +34,8 @@ import (
 	"github.com/gookit/color"
 	"github.com/kubecolor/kubecolor/config"
 	kubecolor "github.com/kubecolor/kubecolor/config/color"
+	"golang.org/x/term"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -51,16 +56,84 @@ type Options struct {
 	ConfigFlags *genericclioptions.ConfigFlags `koanf:"-"`
 	Kubecolor   *config.Config                 `koanf:"-"`
 
-	AllNamespaces   bool                   `koanf:"all-namespaces"`
-	FieldSelector   string                 `koanf:"field-selector"`
-	LabelColumns    []string               `koanf:"label-columns"`
-	LabelSelector   string                 `koanf:"label-selector"`
-	HideDeleted     types.OptionalDuration `koanf:"hide-deleted"`
-	Output          string                 `koanf:"output"`
-	WatchKubeconfig bool                   `koanf:"watch-kubeconfig"`
+	AllContexts        bool                   `koanf:"all-contexts"`
+	AllNamespaces      bool                   `koanf:"all-namespaces"`
+	AnnotationColumns  []string               `koanf:"annotation-columns"`
+	ChunkSize          int64                  `koanf:"chunk-size"`
+	CoalesceWindow     time.Duration          `koanf:"coalesce-window"`
+	ClipboardFile      string                 `koanf:"clipboard-file"`
+	Conditions         bool                   `koanf:"conditions"`
+	Debug              bool                   `koanf:"debug"`
+	ExtraColumns       []string               `koanf:"extra-column"`
+	FieldSelector      string                 `koanf:"field-selector"`
+	LabelColumns       []string               `koanf:"label-columns"`
+	LabelSelector      string                 `koanf:"label-selector"`
+	Metrics            bool                   `koanf:"metrics"`
+	MetricsInterval    time.Duration          `koanf:"metrics-interval"`
+	Notify             []string               `koanf:"notify"`
+	NotifyVia          []string               `koanf:"notify-via"`
+	NotifyCommand      string                 `koanf:"notify-command"`
+	OnEvent            []string               `koanf:"on-event"`
+	OnEventTypes       []string               `koanf:"on-event-types"`
+	OnEventFilter      string                 `koanf:"on-event-filter"`
+	OnEventConcurrency int                    `koanf:"on-event-concurrency"`
+	OnEventTimeout     time.Duration          `koanf:"on-event-timeout"`
+	HideDeleted        types.OptionalDuration `koanf:"hide-deleted"`
+	Output             string                 `koanf:"output"`
+	ReadOnly           bool                   `koanf:"read-only"`
+	SnapshotFormat     string                 `koanf:"snapshot-format"`
+	Sparkline          string                 `koanf:"sparkline"`
+	SparklineInterval  time.Duration          `koanf:"sparkline-interval"`
+	TimeFormat         string                 `koanf:"time-format"`
+	Timezone           string                 `koanf:"timezone"`
+	WatchKubeconfig    bool                   `koanf:"watch-kubeconfig"`
 }
 
 func (o Options) Validate() error {
+	if _, err := o.newNotifier(); err != nil {
+		return err
+	}
+	if _, err := o.newHooks(); err != nil {
+		return err
+	}
+	if _, err := parseExtraColumns(o.ExtraColumns); err != nil {
+		return err
+	}
+	if o.ChunkSize < 0 {
+		return fmt.Errorf("chunk size must not be negative, got: %d", o.ChunkSize)
+	}
+	if o.CoalesceWindow < 0 {
+		return fmt.Errorf("coalesce window must not be negative, got: %s", o.CoalesceWindow)
+	}
+	if o.Metrics && o.MetricsInterval <= 0 {
+		return fmt.Errorf("metrics interval must be positive, got: %s", o.MetricsInterval)
+	}
+	if o.Sparkline != "" {
+		if !slices.Contains(SparklineSources, SparklineSource(o.Sparkline)) {
+			return fmt.Errorf("unknown sparkline source: %q, allowed sources are: %s", o.Sparkline, SparklineSources)
+		}
+		if (o.Sparkline == string(SparklineCPU) || o.Sparkline == string(SparklineMemory)) && !o.Metrics {
+			return fmt.Errorf("sparkline source %q requires the --metrics flag", o.Sparkline)
+		}
+		if o.SparklineInterval <= 0 {
+			return fmt.Errorf("sparkline interval must be positive, got: %s", o.SparklineInterval)
+		}
+	}
+	if o.TimeFormat != "" {
+		if _, err := table.ParseTimeFormat(o.TimeFormat); err != nil {
+			return err
+		}
+	}
+	if o.Timezone != "" {
+		if _, err := time.LoadLocation(o.Timezone); err != nil {
+			return fmt.Errorf("timezone: %w", err)
+		}
+	}
+	if o.SnapshotFormat != "" {
+		if _, err := table.ParseExportFormat(o.SnapshotFormat); err != nil {
+			return err
+		}
+	}
 	const allowedFormats = "wide"
 	switch o.Output {
 	case "", "wide":
@@ -94,6 +167,10 @@ func Execute(o Options, args []string) error {
 	if err := o.Validate(); err != nil {
 		return err
 	}
+	contexts, err := o.watchContexts()
+	if err != nil {
+		return err
+	}
 	var fileEvents chan fsnotify.Event
 	if o.WatchKubeconfig {
 		if fileWatcher, err := fsnotify.NewWatcher(); err == nil {
@@ -109,11 +186,23 @@ func Execute(o Options, args []string) error {
 
 	t := table.New()
 	t.HideDeletedAfter = o.HideDeleted
+	t.StateStyle = StatusStyle
+	if o.SparklineInterval > 0 {
+		t.SparklineInterval = o.SparklineInterval
+	}
+	// Already validated in [Options.Validate]
+	if o.TimeFormat != "" {
+		t.TimeFormat, _ = table.ParseTimeFormat(o.TimeFormat)
+	}
+	if o.Timezone != "" {
+		t.TimeLocation, _ = time.LoadLocation(o.Timezone)
+	}
 
 	if o.Kubecolor != nil {
 		overrideLipglossWithKubecolor(&t.Styles.Header, o.Kubecolor.Theme.Table.Header)
 		overrideLipglossWithKubecolor(&t.Styles.Row.Deleted, o.Kubecolor.Theme.Base.Muted)
 		overrideLipglossWithKubecolor(&t.Styles.Row.Error, o.Kubecolor.Theme.Base.Danger)
+		overrideLipglossWithKubecolor(&t.Styles.Warning, o.Kubecolor.Theme.Base.Warning)
 		overrideLipglossWithKubecolor(&t.Styles.NoneFound, o.Kubecolor.Theme.Base.Muted)
 		overrideLipglossWithKubecolor(&t.Styles.FilterInfo, o.Kubecolor.Theme.Base.Muted)
 		overrideLipglossWithKubecolor(&t.Styles.FilterPrompt, o.Kubecolor.Theme.Base.Secondary)
@@ -133,21 +222,122 @@ func Execute(o Options, args []string) error {
 		overrideLipglossWithKubecolor(&StyleStatusFalse, o.Kubecolor.Theme.Data.False)
 	}
 
+	// Already validated in [Options.Validate]
+	notifier, _ := o.newNotifier()
+	if notifier != nil {
+		notifier.OnError = func(err error) { t.LogError("notify", err) }
+	}
+	hooks, _ := o.newHooks()
+	if hooks != nil {
+		hooks.OnError = func(err error) { t.LogError("on-event", err) }
+	}
+
+	// Already validated in [Options.Validate]
+	extraColumns, _ := parseExtraColumns(o.ExtraColumns)
+
 	printer := Printer{
+		Notifier:         notifier,
+		Hooks:            hooks,
 		Kubecolor:        o.Kubecolor,
 		Table:            t,
 		HideDeletedAfter: o.HideDeleted,
 		WideOutput:       o.Output == "wide",
+		ExtraColumns:     extraColumns,
+		Conditions:       o.Conditions,
 		LabelCols:        o.LabelColumns,
+		AnnotationCols:   o.AnnotationColumns,
+		Sparkline:        SparklineSource(o.Sparkline),
+		Actions:          !o.ReadOnly,
+	}
+	if o.Metrics {
+		printer.Metrics = &metricsStore{}
+	}
+	cb := newClipboard(o.ClipboardFile)
+	t.PaneBindings = append(t.PaneBindings,
+		table.PaneBinding{
+			Key: DefaultKeyMap.ShowEvents,
+			Open: func(row table.Row) table.Pane {
+				return openEventsPane(o.ConfigFlags, &t.Styles, row)
+			},
+		},
+		table.PaneBinding{
+			Key: DefaultKeyMap.ShowLogs,
+			Open: func(row table.Row) table.Pane {
+				return openLogsPane(o.ConfigFlags, &t.Styles, &DefaultKeyMap, row)
+			},
+		},
+		table.PaneBinding{
+			Key: DefaultKeyMap.Copy,
+			Open: func(row table.Row) table.Pane {
+				return openCopyPane(&t.Styles, &DefaultKeyMap, cb, []table.Row{row})
+			},
+			OpenMarked: func(rows []table.Row) table.Pane {
+				return openCopyPane(&t.Styles, &DefaultKeyMap, cb, rows)
+			},
+		},
+	)
+	if !o.ReadOnly {
+		t.PaneBindings = append(t.PaneBindings, table.PaneBinding{
+			Key: DefaultKeyMap.ShowActions,
+			Open: func(row table.Row) table.Pane {
+				return openActionsPane(o.ConfigFlags, &t.Styles, &DefaultKeyMap, []table.Row{row})
+			},
+			OpenMarked: func(rows []table.Row) table.Pane {
+				return openActionsPane(o.ConfigFlags, &t.Styles, &DefaultKeyMap, rows)
+			},
+		})
+	}
+	snapshotFormat := table.ExportText
+	if o.SnapshotFormat != "" {
+		snapshotFormat, _ = table.ParseExportFormat(o.SnapshotFormat)
+	}
+	t.Export = func(headers []string, rows []table.Row) table.Pane {
+		return openExportPane(&t.Styles, &DefaultKeyMap, cb, snapshotFormat, headers, rows)
+	}
+
+	var debug *debugStats
+	if o.Debug {
+		debug = &debugStats{}
+		wrapConfig := o.ConfigFlags.WrapConfigFn
+		o.ConfigFlags.WrapConfigFn = func(config *rest.Config) *rest.Config {
+			if wrapConfig != nil {
+				config = wrapConfig(config)
+			}
+			return debug.WrapConfig(config)
+		}
+		t.DebugInfo = debug.String
+	}
+
+	// Only show the warnings in the table if it's visible
+	warnings := &warningHandler{Output: os.Stderr}
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		warnings.Table = t
 	}
+	wrapConfig := o.ConfigFlags.WrapConfigFn
+	o.ConfigFlags.WrapConfigFn = warnings.WrapConfigFn(wrapConfig, "")
+
 	p := tea.NewProgram(t)
-	w := NewWatcher(o, p, printer, args)
+	var watchers []*Watcher
+	if len(contexts) == 0 {
+		watchers = append(watchers, NewWatcher(o, p, printer, args))
+	}
+	for _, name := range contexts {
+		contextOptions := o
+		contextOptions.ConfigFlags = configFlagsForContext(o.ConfigFlags, name)
+		contextOptions.ConfigFlags.WrapConfigFn = warnings.WrapConfigFn(wrapConfig, name)
+		watchers = append(watchers, NewWatcher(contextOptions, p, printer.forContext(o, name), args))
+		t.SetSourceStatus(name, "connecting", nil)
+	}
 	t.StartSpinner()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	restartChan := make(chan struct{})
-	defer close(restartChan)
+	hooks.Start(ctx, o.OnEventConcurrency)
+	restartChans := make([]chan struct{}, len(watchers))
+	for i := range restartChans {
+		restartChans[i] = make(chan struct{})
+		defer close(restartChans[i])
+	}
 
 	go func() {
 		for {
@@ -160,22 +350,23 @@ func Execute(o Options, args []string) error {
 				if event.Op != fsnotify.Write {
 					continue
 				}
-				restartChan <- struct{}{}
-
-			case err := <-w.ErrorChan():
-				t.SetError(err)
-				p.Send(nil)
+				for _, restartChan := range restartChans {
+					restartChan <- struct{}{}
+				}
 			case <-ctx.Done():
 				return
 			}
 		}
 	}()
 
-	go func() {
-		w.WatchLoop(ctx, restartChan)
-	}()
+	for i, w := range watchers {
+		w.debug = debug
+		go func() {
+			w.WatchLoop(ctx, restartChans[i])
+		}()
+	}
 
-	_, err := p.Run()
+	_, err = p.Run()
 	return err
 }
 
@@ -185,8 +376,6 @@ func NewWatcher(options Options, program *tea.Program, printer Printer, args []s
 		Program: program,
 		Printer: printer,
 		Args:    args,
-
-		errorChan: make(chan error, 3),
 	}
 }
 
@@ -196,11 +385,12 @@ type Watcher struct {
 	Printer Printer
 	Args    []string
 
-	errorChan chan error
-}
-
-func (w *Watcher) ErrorChan() <-chan error {
-	return w.errorChan
+	debug *debugStats
+	// noWatchList is set once the API server is found to not support
+	// [Watcher.watchList], so restarts go straight to a list and watch.
+	noWatchList bool
+	// initialEventsTimeout overrides [defaultInitialEventsTimeout].
+	initialEventsTimeout time.Duration
 }
 
 func (w *Watcher) WatchLoop(ctx context.Context, restartChan <-chan struct{}) error {
@@ -220,17 +410,13 @@ func (w *Watcher) WatchLoop(ctx context.Context, restartChan <-chan struct{}) er
 		clearBeforePrinting = true
 		select {
 		case err := <-watchErrChan:
-			if cmd := w.Printer.Table.StartSpinner(); cmd != nil {
-				w.Program.Send(cmd())
-			}
-			w.errorChan <- fmt.Errorf("restart in 5s: %w", err)
+			w.startSpinner()
+			w.logError(fmt.Errorf("restart in 5s: %w", err))
 			time.Sleep(5 * time.Second)
 			w.Printer.Table.StopSpinner()
-			w.Printer.Table.SetError(nil)
+			w.setStatus("connecting")
 		case <-restartChan:
-			if cmd := w.Printer.Table.StartSpinner(); cmd != nil {
-				w.Program.Send(cmd())
-			}
+			w.startSpinner()
 			// Prevent it from restarting too eagerly when we're told to restart
 			// so the filesystem has time to flush, such as in case of
 			// "kubectx" on bigger kubeconfigs.
@@ -260,6 +446,18 @@ func slidingSleep(dur time.Duration, ch <-chan struct{}) {
 	}
 }
 
+// startSpinner shows the full-screen spinner while restarting the watch.
+// Not used when watching multiple contexts, as the other contexts' rows
+// are still worth showing.
+func (w *Watcher) startSpinner() {
+	if w.Printer.Context != "" {
+		return
+	}
+	if cmd := w.Printer.Table.StartSpinner(); cmd != nil {
+		w.Program.Send(cmd())
+	}
+}
+
 func (w *Watcher) Watch(ctx context.Context) error {
 	return w.watch(ctx, false)
 }
@@ -272,130 +470,379 @@ func (w *Watcher) watch(ctx context.Context, clearBeforePrinting bool) error {
 	if ns == "" {
 		return fmt.Errorf("no namespace selected")
 	}
+	w.setStatus("connecting")
 
+	var includeObject bool
 	r := resource.NewBuilder(w.ConfigFlags).
 		Unstructured().
 		NamespaceParam(ns).DefaultNamespace().AllNamespaces(w.AllNamespaces).
 		// FilenameParam(o.ExplicitNamespace, &o.FilenameOptions).
 		LabelSelectorParam(w.LabelSelector).
 		FieldSelectorParam(w.FieldSelector).
-		// RequestChunksOf(o.ChunkSize).
+		RequestChunksOf(w.ChunkSize).
 		ResourceTypeOrNameArgs(true, w.Args...).
 		SingleResourceType().
 		Latest().
-		TransformRequests(transformRequests).
+		TransformRequests(func(req *rest.Request) {
+			transformRequests(req, includeObject)
+		}).
 		Do()
 	if err := r.Err(); err != nil {
 		return err
 	}
-
-	infos, err := r.Infos()
-	if err != nil {
-		return err
-	}
-
-	if len(infos) != 1 {
-		return fmt.Errorf("expected a single resource info, but got %d", len(infos))
+	// No requests for the resource itself have been sent yet,
+	// so this is in time to affect them.
+	mapping, err := r.ResourceMapping()
+	if err == nil {
+		includeObject = w.Printer.needsFullObject(mapping.Resource)
 	}
-	info := infos[0]
-	obj := info.Object
-	mapping := info.Mapping
+	w.debug.SetFullObjects(includeObject)
 
-	printNamespace := w.Options.AllNamespaces
-	if mapping != nil && mapping.Scope.Name() == meta.RESTScopeNameRoot {
-		// Resource isn't namespaced
-		printNamespace = false
+	var configured bool
+	if mapping != nil && w.canWatchList() {
+		if err := w.configure(ctx, ns, mapping, clearBeforePrinting); err != nil {
+			return err
+		}
+		configured = true
+		watcher, err := w.watchList(ns, mapping, includeObject)
+		if err == nil {
+			err = w.pipeEvents(ctx, watcher, false)
+			if !errors.Is(err, errInitialEventsTimeout) {
+				return err
+			}
+			// The rows received so far may be incomplete or outdated
+			w.Printer.Clear()
+		} else if !isWatchListUnsupported(err) {
+			return err
+		}
+		w.noWatchList = true
+		// Fall back to list+watch
 	}
-	w.Printer.Configure(mapping.GroupVersionKind, printNamespace)
 
 	// watching from resourceVersion 0, starts the watch at ~now and
 	// will return an initial watch event.  Starting form ~now, rather
 	// the resVersion of the object will insure that we start the watch from
 	// inside the watch window, which the resVersion of the object might not be.
 	resVersion := "0"
-	isList := meta.IsListType(obj)
-	var objsToPrint []runtime.Object
-	if isList {
-		// the resourceVersion of list objects is ~now but won't return
-		// an initial watch event
-		resVersion, err = meta.NewAccessor().ResourceVersion(obj)
+	var loaded int
+	// The visitor is called once per chunk when the list is paginated,
+	// so the first chunk is shown while the rest are still being fetched.
+	err = r.Visit(func(info *resource.Info, err error) error {
 		if err != nil {
 			return err
 		}
-		objsToPrint, _ = meta.ExtractList(obj)
-	} else {
-		objsToPrint = []runtime.Object{obj}
-	}
+		if !configured {
+			if err := w.configure(ctx, ns, info.Mapping, clearBeforePrinting); err != nil {
+				return err
+			}
+			configured = true
+		}
 
-	if clearBeforePrinting {
-		w.Printer.Clear()
-	}
+		obj := info.Object
+		var objsToPrint []runtime.Object
+		if meta.IsListType(obj) || isTable(obj) {
+			// the resourceVersion of list objects is ~now but won't return
+			// an initial watch event
+			list, err := meta.ListAccessor(obj)
+			if err != nil {
+				return err
+			}
+			if rv := list.GetResourceVersion(); rv != "" && info.Name == "" {
+				resVersion = rv
+			}
+			loaded += chunkLen(obj)
+			total := 0
+			if remaining := list.GetRemainingItemCount(); remaining != nil {
+				total = loaded + int(*remaining)
+			}
+			w.Printer.Table.SetLoading(loaded, total)
+			if isTable(obj) {
+				objsToPrint = []runtime.Object{obj}
+			} else {
+				objsToPrint, _ = meta.ExtractList(obj)
+			}
+		} else {
+			objsToPrint = []runtime.Object{obj}
+		}
 
-	for _, objToPrint := range objsToPrint {
-		if _, err := w.Printer.PrintObj(objToPrint, watch.Added); err != nil {
-			return err
+		for _, objToPrint := range objsToPrint {
+			if _, err := w.Printer.PrintObj(objToPrint, watch.Added); err != nil {
+				return err
+			}
 		}
+		w.Printer.Table.StopSpinner()
+		return nil
+	})
+	w.Printer.Table.StopLoading()
+	if err != nil {
+		return err
+	}
+	if !configured {
+		return fmt.Errorf("expected a single resource info, but got none")
 	}
 
 	w.Printer.Table.StopSpinner()
+	w.Printer.Notifier.Arm()
+	w.Printer.Hooks.Arm()
+	w.setStatus("watching")
 
-	return w.pipeEvents(ctx, r, resVersion)
+	watcher, err := r.Watch(resVersion)
+	if err != nil {
+		return err
+	}
+	return w.pipeEvents(ctx, watcher, true)
 }
 
-func (w *Watcher) pipeEvents(ctx context.Context, r *resource.Result, resVersion string) error {
-	watch, err := r.Watch(resVersion)
+// configure prepares the printer for the resource, once the first response
+// from the API server has been received.
+func (w *Watcher) configure(ctx context.Context, ns string, mapping *meta.RESTMapping, clearBeforePrinting bool) error {
+	if mapping == nil {
+		return fmt.Errorf("no resource mapping found")
+	}
+	printNamespace := w.Options.AllNamespaces
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		// Resource isn't namespaced
+		printNamespace = false
+	}
+	w.Printer.Configure(mapping, printNamespace)
+	w.Printer.configFlags = w.ConfigFlags
+
+	clients, err := newKubeClients(w.ConfigFlags)
 	if err != nil {
 		return err
 	}
-	defer watch.Stop()
+	w.Printer.fallback = &tableFallback{Clients: clients, GVR: mapping.Resource}
+
+	if len(w.Printer.metricsColumns) > 0 {
+		poller := &metricsPoller{
+			Clients:       clients,
+			Store:         w.Printer.Metrics,
+			Interval:      w.MetricsInterval,
+			OnError:       func(err error) { w.Printer.Table.LogError("metrics", err) },
+			Nodes:         mapping.Resource.Resource == "nodes",
+			LabelSelector: w.LabelSelector,
+		}
+		if !w.AllNamespaces {
+			poller.Namespace = ns
+		}
+		go poller.Run(ctx)
+	}
+
+	if clearBeforePrinting {
+		w.Printer.Clear()
+	}
+	return nil
+}
+
+// isTable reports whether the object is a [metav1.Table] response.
+func isTable(obj runtime.Object) bool {
+	return recognizedTableVersions[obj.GetObjectKind().GroupVersionKind()]
+}
+
+// chunkLen returns the number of items in a list or table response.
+func chunkLen(obj runtime.Object) int {
+	if u, ok := obj.(*unstructured.Unstructured); ok && isTable(obj) {
+		rows, _, _ := unstructured.NestedSlice(u.Object, "rows")
+		return len(rows)
+	}
+	return meta.LenList(obj)
+}
+
+// pipeEvents prints the watch events into the table. When synced is false,
+// the watch is expected to start with the initial state of the resource,
+// ended by a bookmark, as sent by [Watcher.watchList]. If the bookmark
+// doesn't arrive within the initial events timeout, no matter how many other
+// events arrive, then errInitialEventsTimeout is returned.
+//
+// Events received within [Options.CoalesceWindow] of each other are printed
+// together, so that bursts of events only cause a single redraw.
+func (w *Watcher) pipeEvents(ctx context.Context, watcher watch.Interface, synced bool) error {
+	defer watcher.Stop()
+	var batch eventBatch
+	var flushTimer *time.Timer
+	var flushChan <-chan time.Time
+	defer func() {
+		if flushTimer != nil {
+			flushTimer.Stop()
+		}
+	}()
+	flush := func() error {
+		flushChan = nil
+		return w.printEvents(batch.Flush())
+	}
+	var initialEventsTimer *time.Timer
+	var initialEventsChan <-chan time.Time
+	if !synced {
+		initialEventsTimer = time.NewTimer(w.initialEventsTimeoutOrDefault())
+		defer initialEventsTimer.Stop()
+		initialEventsChan = initialEventsTimer.C
+	}
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case event, ok := <-watch.ResultChan():
+		case <-initialEventsChan:
+			return errInitialEventsTimeout
+		case <-flushChan:
+			if err := flush(); err != nil {
+				return err
+			}
+		case event, ok := <-watcher.ResultChan():
+			if !ok || event.Type == watch.Error {
+				// Don't lose any pending events, such as deletions
+				if err := flush(); err != nil {
+					return err
+				}
+			}
 			if !ok {
 				return fmt.Errorf("watch channel closed")
 			}
-			cmd, err := w.Printer.PrintObj(event.Object, event.Type)
-			if err != nil {
-				return err
+			if event.Type == watch.Error {
+				return apierrors.FromObject(event.Object)
+			}
+			if event.Type == watch.Bookmark {
+				if !synced && isInitialEventsEnd(event.Object) {
+					if err := flush(); err != nil {
+						return err
+					}
+					// Only now is an empty table truly "No resources found"
+					synced = true
+					initialEventsTimer.Stop()
+					initialEventsChan = nil
+					w.Printer.Table.StopSpinner()
+					w.Printer.Notifier.Arm()
+					w.Printer.Hooks.Arm()
+					w.setStatus("watching")
+				}
+				continue
+			}
+			batch.Add(event, time.Now())
+			if w.CoalesceWindow <= 0 {
+				if err := flush(); err != nil {
+					return err
+				}
+				continue
+			}
+			if flushChan == nil {
+				if flushTimer == nil {
+					flushTimer = time.NewTimer(w.CoalesceWindow)
+				} else {
+					flushTimer.Reset(w.CoalesceWindow)
+				}
+				flushChan = flushTimer.C
 			}
-			w.Program.Send(cmd())
 		}
 	}
 }
 
+// printEvents prints the events into the table, and then redraws it once.
+func (w *Watcher) printEvents(events []observedEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	cmds := make([]tea.Cmd, 0, len(events))
+	for _, event := range events {
+		cmd, err := w.Printer.printObj(event.Object, event.Type, event.Time)
+		if err != nil {
+			return err
+		}
+		cmds = append(cmds, cmd)
+	}
+	if cmd := tea.Batch(cmds...); cmd != nil {
+		w.Program.Send(cmd())
+	}
+	return nil
+}
+
 type Printer struct {
+	Notifier         *Notifier
+	Hooks            *Hooks
+	Metrics          *metricsStore
 	Kubecolor        *config.Config
 	Table            *table.Model
 	HideDeletedAfter types.OptionalDuration
 	WideOutput       bool
 	colDefs          []metav1.TableColumnDefinition
+	headers          []string
+	ExtraColumns     []extraColumn
+	Conditions       bool
 	LabelCols        []string
+	AnnotationCols   []string
+	Sparkline        SparklineSource
+	Actions          bool
+	metricsColumns   []metricsColumn
+	countdownHeader  string
+	// Context is the kubeconfig context that the rows are from, when
+	// watching multiple contexts. Shown in a leading CONTEXT column.
+	Context       string
+	configFlags   *genericclioptions.ConfigFlags
+	fallback      *tableFallback
+	showSparkline bool
 
 	info           schema.GroupVersionKind
+	resource       schema.GroupVersionResource
+	namespaced     bool
 	apiVersion     string
 	kind           string
 	printNamespace bool
 }
 
-func (p *Printer) Configure(info schema.GroupVersionKind, printNamespace bool) {
-	p.info = info
-	p.apiVersion, p.kind = info.ToAPIVersionAndKind()
+func (p *Printer) Configure(mapping *meta.RESTMapping, printNamespace bool) {
+	p.info = mapping.GroupVersionKind
+	p.resource = mapping.Resource
+	p.namespaced = mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	p.apiVersion, p.kind = p.info.ToAPIVersionAndKind()
 	p.printNamespace = printNamespace
+	p.countdownHeader = countdownHeaderFor(p.resource)
+	p.metricsColumns = nil
+	if p.Metrics != nil {
+		p.metricsColumns = metricsColumnsFor(p.resource)
+	}
+}
+
+// needsFullObject returns true if the table rows need to contain the full
+// objects of the resource, and not only their metadata.
+func (p *Printer) needsFullObject(gvr schema.GroupVersionResource) bool {
+	// Hooks get the objects as JSON, metrics are compared against the
+	// requests and limits in the objects' specs, and the scale action
+	// defaults to the current number of replicas
+	return len(p.ExtraColumns) > 0 || p.Conditions || p.Hooks != nil ||
+		(p.Metrics != nil && metricsColumnsFor(gvr) != nil) ||
+		(p.Actions && slices.Contains(scalableResources, gvr.GroupResource())) ||
+		gvr.GroupResource() == gvrCertificates.GroupResource()
 }
 
 func (p *Printer) Clear() {
-	p.Table.SetRows(nil)
+	if p.Context != "" {
+		// Leave the rows of the other contexts alone
+		p.Table.DeleteRowsFunc(func(row table.Row) bool {
+			obj, ok := rowObjectOf(row)
+			return ok && obj.Context == p.Context
+		})
+	} else {
+		p.Table.SetRows(nil)
+	}
+	p.Notifier.Reset()
+	p.Hooks.Reset()
+	p.Metrics.Reset()
 }
 
 func (p *Printer) PrintObj(obj runtime.Object, eventType watch.EventType) (tea.Cmd, error) {
+	return p.printObj(obj, eventType, time.Now())
+}
+
+// printObj prints the object into the table, where observedAt is when the
+// object's state was observed, such as when its watch event was received.
+func (p *Printer) printObj(obj runtime.Object, eventType watch.EventType, observedAt time.Time) (tea.Cmd, error) {
 	objTable, err := decodeIntoTable(obj)
+	if errors.Is(err, errNotTable) && p.fallback != nil {
+		objTable, err = p.fallback.Table(obj)
+	}
 	if err != nil {
 		return nil, err
 	}
 	p.updateColDefHeaders(objTable)
-	return p.addObjectToTable(objTable, eventType)
+	return p.addObjectToTable(objTable, eventType, observedAt)
 }
 
 func (p *Printer) updateColDefHeaders(objTable *metav1.Table) {
@@ -407,9 +854,15 @@ func (p *Printer) updateColDefHeaders(objTable *metav1.Table) {
 	if p.printNamespace {
 		numColumns++
 	}
+	if p.Context != "" {
+		numColumns++
+	}
 
 	headers := make([]string, 0, numColumns)
 
+	if p.Context != "" {
+		headers = append(headers, "CONTEXT")
+	}
 	if p.printNamespace {
 		headers = append(headers, "NAMESPACE")
 	}
@@ -418,13 +871,54 @@ func (p *Printer) updateColDefHeaders(objTable *metav1.Table) {
 			headers = append(headers, strings.ToUpper(colDef.Name))
 		}
 	}
-	for _, label := range p.LabelCols {
-		headers = append(headers, labelColumnHeader(label))
+	if p.countdownHeader != "" {
+		headers = append(headers, p.countdownHeader)
+	}
+	for _, col := range p.ExtraColumns {
+		headers = append(headers, col.Header)
+	}
+	if p.Conditions {
+		headers = append(headers, "CONDITIONS")
+	}
+	headers = append(headers, metadataColumnHeaders(p.LabelCols, p.AnnotationCols)...)
+	for _, col := range p.metricsColumns {
+		headers = append(headers, col.Header)
+	}
+	p.showSparkline = p.sparklineAvailable(objTable.ColumnDefinitions)
+	if p.showSparkline {
+		headers = append(headers, "TREND")
 	}
 	p.Table.SetHeaders(headers)
+	p.headers = headers
 	p.colDefs = objTable.ColumnDefinitions
 }
 
+// metadataColumnHeaders returns the headers for the label columns followed
+// by the annotation columns. Keys are shortened via [labelColumnHeader],
+// unless multiple keys shorten to the same header (e.g "foo/name" and
+// "bar/name"), in which case those use their full keys instead.
+// Annotations with the same key as a label column get an "ANNOTATION:" prefix.
+func metadataColumnHeaders(labels, annotations []string) []string {
+	keys := slices.Concat(labels, annotations)
+	headers := make([]string, len(keys))
+	counts := map[string]int{}
+	for i, key := range keys {
+		headers[i] = labelColumnHeader(key)
+		counts[headers[i]]++
+	}
+	for i, key := range keys {
+		if counts[headers[i]] > 1 {
+			headers[i] = strings.ToUpper(key)
+		}
+	}
+	for i, annotation := range annotations {
+		if slices.Contains(labels, annotation) {
+			headers[len(labels)+i] = "ANNOTATION:" + headers[len(labels)+i]
+		}
+	}
+	return headers
+}
+
 func labelColumnHeader(label string) string {
 	label = strings.ToUpper(label)
 	index := strings.LastIndexByte(label, '/')
@@ -434,9 +928,11 @@ func labelColumnHeader(label string) string {
 	return label[index+1:]
 }
 
-func (p *Printer) addObjectToTable(objTable *metav1.Table, eventType watch.EventType) (tea.Cmd, error) {
+func (p *Printer) addObjectToTable(objTable *metav1.Table, eventType watch.EventType, observedAt time.Time) (tea.Cmd, error) {
 	var cmd tea.Cmd
 	for _, row := range objTable.Rows {
+		var ready *Fraction
+		var restarts *float64
 		unstrucObj, ok := row.Object.Object.(*unstructured.Unstructured)
 		if !ok {
 			return nil, fmt.Errorf("want *unstructured.Unstructured, got %T", row.Object.Object)
@@ -468,15 +964,33 @@ func (p *Printer) addObjectToTable(objTable *metav1.Table, eventType watch.Event
 			Suggestion:                name,
 			Kubecolor:                 p.Kubecolor,
 			HasLeadingNamespaceColumn: p.printNamespace,
+			HasLeadingContextColumn:   p.Context != "",
+			ObservedAt:                observedAt,
+			Object: &rowObject{
+				GVK:         p.info,
+				Resource:    p.resource,
+				Namespaced:  p.namespaced,
+				Object:      unstrucObj,
+				Context:     p.Context,
+				ConfigFlags: p.configFlags,
+			},
 		}
 		if p.apiVersion == "v1" && p.kind == "Event" {
 			tableRow.SortKey = creationTimestamp
 		}
+		if p.Context != "" {
+			// The same object may exist in multiple clusters
+			tableRow.ID = fmt.Sprintf("%s/%s", p.Context, uid)
+			tableRow.Fields = append(tableRow.Fields, p.Context)
+		}
 		if p.printNamespace {
 			namespace := metadata["namespace"]
 			tableRow.Fields = append(tableRow.Fields, namespace)
 			tableRow.SortKey = fmt.Sprintf("%s/%s", namespace, tableRow.SortKey)
 		}
+		if p.Context != "" {
+			tableRow.SortKey = fmt.Sprintf("%s/%s", p.Context, tableRow.SortKey)
+		}
 		for i, cell := range row.Cells {
 			if i >= len(p.colDefs) {
 				return nil, fmt.Errorf("cant find index %d (%v) in column defs: %v", i, cell, p.colDefs)
@@ -485,18 +999,68 @@ func (p *Printer) addObjectToTable(objTable *metav1.Table, eventType watch.Event
 			if colDef.Priority != 0 && !p.WideOutput {
 				continue
 			}
+			if strings.EqualFold(colDef.Name, "status") {
+				tableRow.State = fmt.Sprint(cell)
+			}
+			if strings.EqualFold(colDef.Name, "ready") {
+				if f, ok := ParseFraction(fmt.Sprint(cell)); ok {
+					ready = &f
+				}
+			}
+			if strings.EqualFold(colDef.Name, "restarts") {
+				countStr, _, _ := parsePodRestarts(fmt.Sprint(cell))
+				if count, err := strconv.ParseFloat(countStr, 64); err == nil {
+					restarts = &count
+				}
+			}
 			tableRow.Fields = append(tableRow.Fields, p.parseCell(cell, row, eventType, unstrucObj.Object, colDef, creationTime))
 		}
+		if p.countdownHeader != "" {
+			tableRow.Fields = append(tableRow.Fields, p.countdownValue(row, unstrucObj.Object, eventType == watch.Deleted))
+		}
+		for _, col := range p.ExtraColumns {
+			tableRow.Fields = append(tableRow.Fields, col.Value(unstrucObj.Object))
+		}
+		if p.Conditions {
+			tableRow.Fields = append(tableRow.Fields, ConditionsColumn(unstrucObj.Object, eventType == watch.Deleted))
+		}
 		objLabels := unstrucObj.GetLabels()
 		for _, label := range p.LabelCols {
 			tableRow.Fields = append(tableRow.Fields, objLabels[label])
 		}
+		objAnnotations := unstrucObj.GetAnnotations()
+		for _, annotation := range p.AnnotationCols {
+			tableRow.Fields = append(tableRow.Fields, objAnnotations[annotation])
+		}
+		key := metricsKey(unstrucObj.GetNamespace(), name)
+		if len(p.metricsColumns) > 0 {
+			p.Metrics.SetResources(key, resourcesOf(p.resource, unstrucObj.Object))
+		}
+		for _, col := range p.metricsColumns {
+			tableRow.Fields = append(tableRow.Fields, p.Metrics.Column(key, col, eventType == watch.Deleted))
+		}
+		if len(p.metricsColumns) > 0 && eventType == watch.Deleted {
+			p.Metrics.Forget(key)
+		}
+		if p.showSparkline {
+			tableRow.Fields = append(tableRow.Fields, table.SparklineColumn{
+				Sample: p.sparklineSample(key, ready, restarts),
+			})
+		}
 		switch eventType {
 		case watch.Error:
 			tableRow.Status = table.StatusError
+		case watch.Added:
+			if tableRow.State == "" {
+				tableRow.State = "Added"
+			}
 		case watch.Deleted:
 			tableRow.MarkDeleted()
+			tableRow.State = "Deleted"
 		}
+		p.Notifier.Observe(tableRow, eventType, ready)
+		p.Hooks.Observe(tableRow, eventType, p.headers)
+
 		// it's fine to only use the latest returned cmd, because of how
 		// [table.Model.AddRow] is implemented
 
@@ -514,13 +1078,19 @@ func (p *Printer) parseCell(cell any, row metav1.TableRow, eventType watch.Event
 		columnNameLower == "created at":
 		return creationTime
 	case p.apiVersion == "v1" && p.kind == "Event" && columnNameLower == "last seen",
-		p.apiVersion == "batch/v1" && p.kind == "CronJob" && columnNameLower == "last schedule":
+		p.apiVersion == "batch/v1" && p.kind == "CronJob" && columnNameLower == "last schedule",
+		// e.g CRD columns of type "date", which may be in the future
+		colDef.Type == "date":
 
-		dur, ok := util.ParseHumanDuration(cellStr)
+		if t, ok := cell.(time.Time); ok {
+			// Already parsed by the [tableFallback]
+			return t
+		}
+		t, ok := util.ParseHumanTime(cellStr)
 		if !ok {
 			return cell
 		}
-		return time.Now().Add(-dur)
+		return t
 	case p.apiVersion == "batch/v1" && p.kind == "Job" && columnNameLower == "duration":
 		var completionsCell any
 		for i, otherCell := range row.Cells {
@@ -592,7 +1162,11 @@ func (p *Printer) parseCell(cell any, row metav1.TableRow, eventType watch.Event
 	}
 }
 
-func transformRequests(req *rest.Request) {
+// transformRequests makes the requests return tables. When includeObject
+// is true, the table rows contain the full objects as needed by
+// [Printer.needsFullObject], and otherwise only their metadata, which
+// keeps watches of big objects (e.g Secrets) light.
+func transformRequests(req *rest.Request, includeObject bool) {
 	// TODO: Skip if custom column output mode
 
 	//if !o.ServerPrint || !o.IsHumanReadablePrinter {
@@ -604,6 +1178,11 @@ func transformRequests(req *rest.Request) {
 		fmt.Sprintf("application/json;as=Table;v=%s;g=%s", metav1beta1.SchemeGroupVersion.Version, metav1beta1.GroupName),
 		"application/json",
 	}, ","))
+	if includeObject {
+		req.Param("includeObject", string(metav1.IncludeObject))
+	} else {
+		req.Param("includeObject", string(metav1.IncludeMetadata))
+	}
 }
 
 func overrideLipglossWithKubecolor(style *lipgloss.Style, c kubecolor.Color) {
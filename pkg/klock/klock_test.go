@@ -18,12 +18,16 @@
 package klock
 
 import (
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/applejag/kubectl-klock/pkg/table"
 	"github.com/charmbracelet/lipgloss"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 )
 
@@ -62,6 +66,41 @@ func TestParseCellFractionColoring(t *testing.T) {
 	}
 }
 
+func TestParseCellDate(t *testing.T) {
+	tests := []struct {
+		name      string
+		cellValue string
+		want      time.Duration
+	}{
+		{
+			name:      "past",
+			cellValue: "3d",
+			want:      -72 * time.Hour,
+		},
+		{
+			name:      "future",
+			cellValue: "in 2h10m",
+			want:      2*time.Hour + 10*time.Minute,
+		},
+	}
+
+	p := &Printer{}
+	colDef := metav1.TableColumnDefinition{Name: "Expires", Type: "date"}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := p.parseCell(test.cellValue, metav1.TableRow{}, watch.Added, nil, colDef, time.Now())
+			gotTime, ok := got.(time.Time)
+			if !ok {
+				t.Fatalf("expected time.Time, got %T (%v)", got, got)
+			}
+			if diff := time.Until(gotTime) - test.want; diff < -time.Minute || diff > time.Minute {
+				t.Errorf("want %s from now, got %s", test.want, time.Until(gotTime))
+			}
+		})
+	}
+}
+
 func TestLabelColumnHeader(t *testing.T) {
 	tests := []struct {
 		input string
@@ -103,6 +142,43 @@ func TestLabelColumnHeader(t *testing.T) {
 	}
 }
 
+func TestMetadataColumnHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      []string
+		annotations []string
+		want        []string
+	}{
+		{
+			name:        "short",
+			labels:      []string{"app.kubernetes.io/name"},
+			annotations: []string{"deployment.kubernetes.io/revision"},
+			want:        []string{"NAME", "REVISION"},
+		},
+		{
+			name:        "clashing",
+			labels:      []string{"foo/name", "app"},
+			annotations: []string{"bar/name"},
+			want:        []string{"FOO/NAME", "APP", "BAR/NAME"},
+		},
+		{
+			name:        "same key as label",
+			labels:      []string{"example.com/owner"},
+			annotations: []string{"example.com/owner"},
+			want:        []string{"EXAMPLE.COM/OWNER", "ANNOTATION:EXAMPLE.COM/OWNER"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := metadataColumnHeaders(test.labels, test.annotations)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("value did not match\nwant: %q\ngot:  %q", test.want, got)
+			}
+		})
+	}
+}
+
 func TestValidateArgs(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -143,3 +219,106 @@ func TestValidateArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestChunkLen(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  runtime.Object
+		want int
+	}{
+		{
+			name: "table",
+			obj: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "meta.k8s.io/v1",
+				"kind":       "Table",
+				"rows":       []any{map[string]any{}, map[string]any{}},
+			}},
+			want: 2,
+		},
+		{
+			name: "list",
+			obj: &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+				{Object: map[string]any{"kind": "Pod"}},
+				{Object: map[string]any{"kind": "Pod"}},
+				{Object: map[string]any{"kind": "Pod"}},
+			}},
+			want: 3,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := chunkLen(tc.obj); got != tc.want {
+				t.Errorf("want %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNeedsFullObject(t *testing.T) {
+	gvrPods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	tests := []struct {
+		name    string
+		printer Printer
+		gvr     schema.GroupVersionResource
+		want    bool
+	}{
+		{
+			name: "only metadata",
+			gvr:  gvrPods,
+			want: false,
+		},
+		{
+			name:    "extra columns",
+			printer: Printer{ExtraColumns: []extraColumn{{Header: "NODE"}}},
+			gvr:     gvrPods,
+			want:    true,
+		},
+		{
+			name:    "conditions",
+			printer: Printer{Conditions: true},
+			gvr:     gvrPods,
+			want:    true,
+		},
+		{
+			name:    "on-event hooks",
+			printer: Printer{Hooks: &Hooks{}},
+			gvr:     gvrPods,
+			want:    true,
+		},
+		{
+			name:    "metrics",
+			printer: Printer{Metrics: &metricsStore{}},
+			gvr:     gvrPods,
+			want:    true,
+		},
+		{
+			name:    "metrics for resource without metrics",
+			printer: Printer{Metrics: &metricsStore{}},
+			gvr:     gvrDeployments,
+			want:    false,
+		},
+		{
+			name:    "scale action",
+			printer: Printer{Actions: true},
+			gvr:     gvrDeployments,
+			want:    true,
+		},
+		{
+			name: "scale action when read-only",
+			gvr:  gvrDeployments,
+			want: false,
+		},
+		{
+			name: "certificates countdown",
+			gvr:  gvrCertificates,
+			want: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.printer.needsFullObject(tc.gvr); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
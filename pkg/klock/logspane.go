@@ -0,0 +1,470 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+const (
+	// logsMaxLines is the maximum number of log lines kept in the logs pane.
+	logsMaxLines = 5000
+	// logsTailLines is how many lines of old logs to show when attaching.
+	logsTailLines int64 = 1000
+
+	logsReattachedLine = "--- reattached ---"
+)
+
+var StyleLogsMatch = lipgloss.NewStyle().Reverse(true)
+
+// logsPane shows the logs of a container in the selected pod, similar to
+// "kubectl logs --follow".
+type logsPane struct {
+	styles    *table.Styles
+	keys      *KeyMap
+	client    kubernetes.Interface
+	namespace string
+	name      string
+
+	containers []string
+	container  int
+	follow     bool
+	previous   bool
+
+	lines  []string
+	scroll int // number of lines scrolled up from the bottom
+	height int // last rendered height, used for page up/down
+
+	searchInput textinput.Model
+	searching   bool
+	searchTerm  string
+
+	ctx          context.Context
+	cancel       context.CancelFunc
+	ch           chan tea.Msg
+	streamCancel context.CancelFunc
+	streamID     int
+	streaming    bool
+	err          error
+}
+
+type logsPodMsg struct {
+	pane       *logsPane
+	containers []string
+	container  int
+	err        error
+}
+
+type logsLinesMsg struct {
+	pane     *logsPane
+	streamID int
+	lines    []string
+}
+
+type logsEndMsg struct {
+	pane     *logsPane
+	streamID int
+	err      error
+}
+
+var (
+	_ table.RowPane   = &logsPane{}
+	_ table.InputPane = &logsPane{}
+)
+
+func openLogsPane(configFlags *genericclioptions.ConfigFlags, styles *table.Styles, keys *KeyMap, row table.Row) table.Pane {
+	obj, ok := rowObjectOf(row)
+	if !ok || !obj.Is("", "Pod") {
+		return nil
+	}
+	client, err := newClientset(obj.configFlagsOr(configFlags))
+	if err != nil {
+		return &logsPane{styles: styles, keys: keys, err: err}
+	}
+	return newLogsPane(client, styles, keys, obj.Object.GetNamespace(), obj.Object.GetName())
+}
+
+func newLogsPane(client kubernetes.Interface, styles *table.Styles, keys *KeyMap, namespace, name string) *logsPane {
+	ctx, cancel := context.WithCancel(context.Background())
+	searchInput := textinput.New()
+	searchInput.Prompt = "/"
+	return &logsPane{
+		styles:    styles,
+		keys:      keys,
+		client:    client,
+		namespace: namespace,
+		name:      name,
+		follow:    true,
+
+		searchInput: searchInput,
+
+		ctx:    ctx,
+		cancel: cancel,
+		ch:     make(chan tea.Msg),
+	}
+}
+
+func (p *logsPane) Init() tea.Cmd {
+	if p.client == nil {
+		return nil
+	}
+	go p.fetchContainers()
+	return p.waitForMsg()
+}
+
+func (p *logsPane) Close() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+func (p *logsPane) CapturesInput() bool {
+	return p.searching
+}
+
+// SetRow is called whenever the pod is updated by the watch. If the log
+// stream has ended, such as when the container was restarted, then we
+// try to reattach to the new container.
+func (p *logsPane) SetRow(table.Row) tea.Cmd {
+	if p.follow && !p.streaming && len(p.containers) > 0 && p.ctx.Err() == nil {
+		if len(p.lines) == 0 || p.lines[len(p.lines)-1] != logsReattachedLine {
+			p.lines = appendLogLines(p.lines, logsReattachedLine)
+		}
+		p.startStream()
+	}
+	return nil
+}
+
+func (p *logsPane) waitForMsg() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case msg := <-p.ch:
+			return msg
+		case <-p.ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (p *logsPane) send(msg tea.Msg) bool {
+	select {
+	case p.ch <- msg:
+		return true
+	case <-p.ctx.Done():
+		return false
+	}
+}
+
+func (p *logsPane) fetchContainers() {
+	pod, err := p.client.CoreV1().Pods(p.namespace).Get(p.ctx, p.name, metav1.GetOptions{})
+	if err != nil {
+		p.send(logsPodMsg{pane: p, err: fmt.Errorf("get pod: %w", err)})
+		return
+	}
+	var containers []string
+	for _, c := range pod.Spec.InitContainers {
+		containers = append(containers, c.Name)
+	}
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, c.Name)
+	}
+	p.send(logsPodMsg{
+		pane:       p,
+		containers: containers,
+		container:  defaultContainerIndex(pod, containers),
+	})
+}
+
+// defaultContainerIndex picks the container to show first, using the same
+// annotation as "kubectl logs", and otherwise the first non-init container.
+func defaultContainerIndex(pod *corev1.Pod, containers []string) int {
+	if name := pod.Annotations["kubectl.kubernetes.io/default-container"]; name != "" {
+		for i, c := range containers {
+			if c == name {
+				return i
+			}
+		}
+	}
+	return min(len(pod.Spec.InitContainers), len(containers)-1)
+}
+
+func (p *logsPane) startStream() {
+	if p.streamCancel != nil {
+		p.streamCancel()
+	}
+	p.streamID++
+	p.streaming = true
+	p.err = nil
+	ctx, cancel := context.WithCancel(p.ctx)
+	p.streamCancel = cancel
+
+	tailLines := logsTailLines
+	opts := &corev1.PodLogOptions{
+		Container: p.containers[p.container],
+		Follow:    p.follow,
+		Previous:  p.previous,
+		TailLines: &tailLines,
+	}
+	go p.stream(ctx, p.streamID, opts)
+}
+
+func (p *logsPane) stream(ctx context.Context, streamID int, opts *corev1.PodLogOptions) {
+	body, err := p.client.CoreV1().Pods(p.namespace).GetLogs(p.name, opts).Stream(ctx)
+	if err != nil {
+		p.send(logsEndMsg{pane: p, streamID: streamID, err: err})
+		return
+	}
+	defer body.Close()
+
+	reader := bufio.NewReader(body)
+	var batch []string
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			batch = append(batch, strings.TrimRight(line, "\r\n"))
+		}
+		// Send lines in batches, to not re-render for every line
+		if len(batch) > 0 && (err != nil || reader.Buffered() == 0 || len(batch) >= 100) {
+			if !p.send(logsLinesMsg{pane: p, streamID: streamID, lines: batch}) {
+				return
+			}
+			batch = nil
+		}
+		if err != nil {
+			if err == io.EOF || ctx.Err() != nil {
+				err = nil
+			}
+			p.send(logsEndMsg{pane: p, streamID: streamID, err: err})
+			return
+		}
+	}
+}
+
+func appendLogLines(lines []string, newLines ...string) []string {
+	lines = append(lines, newLines...)
+	if len(lines) > logsMaxLines {
+		lines = append([]string(nil), lines[len(lines)-logsMaxLines:]...)
+	}
+	return lines
+}
+
+func (p *logsPane) restartStream() {
+	if len(p.containers) == 0 {
+		return
+	}
+	p.lines = nil
+	p.scroll = 0
+	p.startStream()
+}
+
+func (p *logsPane) Update(msg tea.Msg) (table.Pane, tea.Cmd) {
+	switch msg := msg.(type) {
+	case logsPodMsg:
+		if msg.pane != p {
+			return p, nil
+		}
+		p.err = msg.err
+		p.containers = msg.containers
+		p.container = msg.container
+		if len(p.containers) > 0 {
+			p.startStream()
+		}
+		return p, p.waitForMsg()
+	case logsLinesMsg:
+		if msg.pane != p {
+			return p, nil
+		}
+		if msg.streamID == p.streamID {
+			p.lines = appendLogLines(p.lines, msg.lines...)
+			if p.scroll > 0 {
+				// Keep the same lines in view while scrolled up
+				// Clamp, as the oldest lines may have been trimmed
+				p.scroll = min(p.scroll+len(msg.lines), len(p.lines)-1)
+			}
+		}
+		return p, p.waitForMsg()
+	case logsEndMsg:
+		if msg.pane != p {
+			return p, nil
+		}
+		if msg.streamID == p.streamID {
+			p.streaming = false
+			p.err = msg.err
+		}
+		return p, p.waitForMsg()
+	case tea.KeyMsg:
+		return p, p.updateKey(msg)
+	}
+	return p, nil
+}
+
+func (p *logsPane) updateKey(msg tea.KeyMsg) tea.Cmd {
+	if p.searching {
+		switch msg.Type {
+		case tea.KeyEnter:
+			p.searching = false
+			p.searchInput.Blur()
+			p.searchTerm = p.searchInput.Value()
+			p.scroll = 0
+			p.jumpToMatch(+1)
+			return nil
+		case tea.KeyEsc:
+			p.searching = false
+			p.searchInput.Blur()
+			return nil
+		}
+		var cmd tea.Cmd
+		p.searchInput, cmd = p.searchInput.Update(msg)
+		return cmd
+	}
+
+	switch {
+	case key.Matches(msg, p.keys.LogsNextContainer):
+		if len(p.containers) > 1 {
+			p.container = (p.container + 1) % len(p.containers)
+			p.restartStream()
+		}
+	case key.Matches(msg, p.keys.LogsToggleFollow):
+		p.follow = !p.follow
+		p.restartStream()
+	case key.Matches(msg, p.keys.LogsTogglePrevious):
+		p.previous = !p.previous
+		p.restartStream()
+	case key.Matches(msg, p.keys.LogsSearch):
+		p.searching = true
+		p.searchInput.SetValue("")
+		return p.searchInput.Focus()
+	case key.Matches(msg, p.keys.LogsNextMatch):
+		p.jumpToMatch(+1)
+	case key.Matches(msg, p.keys.LogsPrevMatch):
+		p.jumpToMatch(-1)
+	case key.Matches(msg, p.keys.LogsScrollUp):
+		p.scrollBy(1)
+	case key.Matches(msg, p.keys.LogsScrollDown):
+		p.scrollBy(-1)
+	case key.Matches(msg, p.keys.LogsPageUp):
+		p.scrollBy(max(p.height-2, 1))
+	case key.Matches(msg, p.keys.LogsPageDown):
+		p.scrollBy(-max(p.height-2, 1))
+	case key.Matches(msg, p.keys.LogsGoToStart):
+		p.scrollBy(len(p.lines))
+	case key.Matches(msg, p.keys.LogsGoToEnd):
+		p.scroll = 0
+	}
+	return nil
+}
+
+func (p *logsPane) scrollBy(delta int) {
+	p.scroll = max(min(p.scroll+delta, len(p.lines)-1), 0)
+}
+
+// jumpToMatch scrolls to the next older (+1) or newer (-1) line that
+// contains the search term.
+func (p *logsPane) jumpToMatch(direction int) {
+	if p.searchTerm == "" || len(p.lines) == 0 {
+		return
+	}
+	bottom := len(p.lines) - 1 - p.scroll
+	for i := 1; i <= len(p.lines); i++ {
+		index := bottom - direction*i
+		if index < 0 || index >= len(p.lines) {
+			return
+		}
+		if strings.Contains(p.lines[index], p.searchTerm) {
+			p.scroll = len(p.lines) - 1 - index
+			return
+		}
+	}
+}
+
+func (p *logsPane) View(width, height int) string {
+	var sb strings.Builder
+	sb.WriteString(p.styles.PaneTitle.Render("LOGS:"))
+	if len(p.containers) > 0 {
+		sb.WriteByte(' ')
+		sb.WriteString(p.containers[p.container])
+	}
+	var toggles []string
+	if p.follow {
+		toggles = append(toggles, "follow")
+	}
+	if p.previous {
+		toggles = append(toggles, "previous")
+	}
+	if p.scroll > 0 {
+		toggles = append(toggles, fmt.Sprintf("scrolled up %d lines", p.scroll))
+	}
+	if !p.streaming && len(p.containers) > 0 {
+		toggles = append(toggles, "not attached")
+	}
+	if len(toggles) > 0 {
+		sb.WriteString(p.styles.Toggles.Render(" (" + strings.Join(toggles, ", ") + ")"))
+	}
+	if p.err != nil {
+		sb.WriteByte(' ')
+		sb.WriteString(p.styles.Error.Render(p.err.Error()))
+	}
+
+	numLines := height - 1
+	if p.searching || p.searchTerm != "" {
+		numLines--
+	}
+	p.height = numLines
+	if end := len(p.lines) - p.scroll; numLines > 0 && end >= 0 {
+		start := max(end-numLines, 0)
+		for _, line := range p.lines[start:end] {
+			sb.WriteByte('\n')
+			if width > 0 {
+				line = truncate.StringWithTail(line, uint(width), "…")
+			}
+			sb.WriteString(p.highlightMatches(line))
+		}
+	}
+
+	if p.searching {
+		sb.WriteByte('\n')
+		sb.WriteString(p.searchInput.View())
+	} else if p.searchTerm != "" {
+		sb.WriteByte('\n')
+		sb.WriteString(p.styles.FilterInfo.UnsetString().Render(fmt.Sprintf("searching for %q (n/N for next/previous match)", p.searchTerm)))
+	}
+	return sb.String()
+}
+
+func (p *logsPane) highlightMatches(line string) string {
+	if p.searchTerm == "" || !strings.Contains(line, p.searchTerm) {
+		return line
+	}
+	return strings.ReplaceAll(line, p.searchTerm, StyleLogsMatch.Render(p.searchTerm))
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func newFakeLogsClient() *fake.Clientset {
+	client := fake.NewClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-pod"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "setup"}},
+			Containers:     []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+		},
+	})
+	client.PrependReactor("get", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "log" {
+			return false, nil, nil
+		}
+		opts := action.(k8stesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+		prefix := opts.Container
+		if opts.Previous {
+			prefix += " previous"
+		}
+		logs := fmt.Sprintf("%[1]s line 1\n%[1]s line 2\n", prefix)
+		return true, &runtime.Unknown{Raw: []byte(logs)}, nil
+	})
+	return client
+}
+
+// runLogsPane runs the pane's commands until the log stream has ended.
+func runLogsPane(t *testing.T, p *logsPane, cmd tea.Cmd) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for cmd != nil {
+		msgChan := make(chan tea.Msg, 1)
+		go func() { msgChan <- cmd() }()
+		select {
+		case msg := <-msgChan:
+			_, cmd = p.Update(msg)
+		case <-deadline:
+			t.Fatal("timed out waiting for logs pane")
+		}
+		if !p.streaming && len(p.containers) > 0 {
+			return
+		}
+	}
+}
+
+func pressKey(p *logsPane, keys string) {
+	for _, r := range keys {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestLogsPane(t *testing.T) {
+	styles := table.DefaultStyles
+	p := newLogsPane(newFakeLogsClient(), &styles, &DefaultKeyMap, "default", "my-pod")
+	defer p.Close()
+
+	runLogsPane(t, p, p.Init())
+	if got, want := strings.Join(p.containers, ","), "setup,app,sidecar"; got != want {
+		t.Fatalf("wrong containers\nwant: %q\ngot:  %q", want, got)
+	}
+	if got, want := strings.Join(p.lines, "|"), "app line 1|app line 2"; got != want {
+		t.Errorf("wrong lines for default container\nwant: %q\ngot:  %q", want, got)
+	}
+
+	t.Run("next container", func(t *testing.T) {
+		pressKey(p, "c")
+		runLogsPane(t, p, p.waitForMsg())
+		if got, want := strings.Join(p.lines, "|"), "sidecar line 1|sidecar line 2"; got != want {
+			t.Errorf("wrong lines\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("previous", func(t *testing.T) {
+		pressKey(p, "p")
+		runLogsPane(t, p, p.waitForMsg())
+		if got, want := strings.Join(p.lines, "|"), "sidecar previous line 1|sidecar previous line 2"; got != want {
+			t.Errorf("wrong lines\nwant: %q\ngot:  %q", want, got)
+		}
+		pressKey(p, "p")
+		runLogsPane(t, p, p.waitForMsg())
+	})
+
+	t.Run("reattach on pod update", func(t *testing.T) {
+		p.SetRow(table.Row{})
+		runLogsPane(t, p, p.waitForMsg())
+		want := "sidecar line 1|sidecar line 2|" + logsReattachedLine + "|sidecar line 1|sidecar line 2"
+		if got := strings.Join(p.lines, "|"); got != want {
+			t.Errorf("wrong lines\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		pressKey(p, "/")
+		if !p.CapturesInput() {
+			t.Fatal("want pane to capture input while searching")
+		}
+		pressKey(p, "line 1")
+		p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if p.CapturesInput() {
+			t.Fatal("want pane to stop capturing input after search")
+		}
+		if got, want := p.scroll, 1; got != want {
+			t.Errorf("want scrolled to latest match %d lines up, got %d", want, got)
+		}
+		pressKey(p, "n")
+		if got, want := p.scroll, 4; got != want {
+			t.Errorf("want scrolled to older match %d lines up, got %d", want, got)
+		}
+		pressKey(p, "N")
+		if got, want := p.scroll, 1; got != want {
+			t.Errorf("want scrolled back to newer match %d lines up, got %d", want, got)
+		}
+	})
+}
+
+func TestLogsPaneScrolledUpFullBuffer(t *testing.T) {
+	styles := table.DefaultStyles
+	p := newLogsPane(newFakeLogsClient(), &styles, &DefaultKeyMap, "default", "my-pod")
+	defer p.Close()
+
+	for i := range logsMaxLines {
+		p.lines = append(p.lines, fmt.Sprint("line ", i))
+	}
+	pressKey(p, "g")
+	if got, want := p.scroll, logsMaxLines-1; got != want {
+		t.Fatalf("want scrolled to the top %d lines up, got %d", want, got)
+	}
+	for range 2 {
+		p.Update(logsLinesMsg{pane: p, streamID: p.streamID, lines: []string{"new 1", "new 2"}})
+	}
+	if len(p.lines) != logsMaxLines {
+		t.Errorf("want %d lines, got %d", logsMaxLines, len(p.lines))
+	}
+	if got, want := p.scroll, logsMaxLines-1; got != want {
+		t.Errorf("want scroll clamped to %d, got %d", want, got)
+	}
+	if view := p.View(80, 10); !strings.Contains(view, "line 4") {
+		t.Errorf("want oldest kept line in view, got:\n%s", view)
+	}
+}
@@ -0,0 +1,397 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+var (
+	gvrPodMetrics  = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	gvrNodeMetrics = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+)
+
+// Percentages at or above these are colored as warnings or errors.
+const (
+	metricsWarningPercent = 70
+	metricsErrorPercent   = 90
+)
+
+// resourceUsage is the CPU and memory usage of a pod or node, together with
+// what it's compared against. CPU is in millicores and memory in bytes.
+// Zero means the value is not set.
+type resourceUsage struct {
+	// Measured is false when metrics-server has no metrics for the object,
+	// such as for newly created pods.
+	Measured bool
+	CPU      int64
+	Memory   int64
+
+	// Set for pods, summed up from the pod's containers.
+	CPURequest    int64
+	CPULimit      int64
+	MemoryRequest int64
+	MemoryLimit   int64
+
+	// Set for nodes.
+	CPUAllocatable    int64
+	MemoryAllocatable int64
+}
+
+type metricsColumn struct {
+	Header string
+	Value  func(u resourceUsage) any
+}
+
+var podMetricsColumns = []metricsColumn{
+	{Header: "CPU", Value: func(u resourceUsage) any { return formatMilliCPU(u.CPU) }},
+	{Header: "MEM", Value: func(u resourceUsage) any { return formatMemory(u.Memory) }},
+	{Header: "%CPU/R", Value: func(u resourceUsage) any { return percentColumn(u.CPU, u.CPURequest) }},
+	{Header: "%CPU/L", Value: func(u resourceUsage) any { return percentColumn(u.CPU, u.CPULimit) }},
+	{Header: "%MEM/R", Value: func(u resourceUsage) any { return percentColumn(u.Memory, u.MemoryRequest) }},
+	{Header: "%MEM/L", Value: func(u resourceUsage) any { return percentColumn(u.Memory, u.MemoryLimit) }},
+}
+
+var nodeMetricsColumns = []metricsColumn{
+	{Header: "CPU", Value: func(u resourceUsage) any { return formatMilliCPU(u.CPU) }},
+	{Header: "%CPU", Value: func(u resourceUsage) any { return percentColumn(u.CPU, u.CPUAllocatable) }},
+	{Header: "MEM", Value: func(u resourceUsage) any { return formatMemory(u.Memory) }},
+	{Header: "%MEM", Value: func(u resourceUsage) any { return percentColumn(u.Memory, u.MemoryAllocatable) }},
+}
+
+// metricsColumnsFor returns the metrics columns to show for the resource,
+// or nil if metrics-server doesn't have metrics for it.
+func metricsColumnsFor(gvr schema.GroupVersionResource) []metricsColumn {
+	if gvr.Group != "" {
+		return nil
+	}
+	switch gvr.Resource {
+	case "pods":
+		return podMetricsColumns
+	case "nodes":
+		return nodeMetricsColumns
+	default:
+		return nil
+	}
+}
+
+func formatMilliCPU(milli int64) string {
+	return fmt.Sprintf("%dm", milli)
+}
+
+func formatMemory(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/(1024*1024))
+}
+
+func percentColumn(value, total int64) any {
+	if total == 0 {
+		return "-"
+	}
+	percent := value * 100 / total
+	return table.StyledColumn{
+		Value: fmt.Sprintf("%d%%", percent),
+		Style: percentStyle(percent),
+	}
+}
+
+func percentStyle(percent int64) lipgloss.Style {
+	switch {
+	case percent >= metricsErrorPercent:
+		return StyleStatusError
+	case percent >= metricsWarningPercent:
+		return StyleStatusWarning
+	default:
+		return StyleStatusDefault
+	}
+}
+
+func metricsKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// metricsStore holds the latest polled metrics. It's read when rendering the
+// rows, and written to by the [metricsPoller].
+//
+// The requests, limits, and allocatable resources that the usage is compared
+// against are taken from the watched objects instead, via
+// [metricsStore.SetResources], so they don't have to be listed on every poll.
+type metricsStore struct {
+	mu          sync.RWMutex
+	usage       map[string]resourceUsage
+	resources   map[string]resourceUsage
+	polled      bool
+	unavailable bool
+}
+
+// SetResources stores the requests, limits, and allocatable resources of
+// the watched object, as returned by [resourcesOf].
+func (s *metricsStore) SetResources(key string, resources resourceUsage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.resources == nil {
+		s.resources = map[string]resourceUsage{}
+	}
+	s.resources[key] = resources
+}
+
+// Forget removes the stored resources of the object, such as when it has
+// been deleted.
+func (s *metricsStore) Forget(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.resources, key)
+}
+
+func (s *metricsStore) set(usage map[string]resourceUsage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage = usage
+	s.polled = true
+	s.unavailable = false
+}
+
+func (s *metricsStore) setUnavailable() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage = nil
+	s.polled = true
+	s.unavailable = true
+}
+
+// Reset clears all metrics, such as when switching to a different cluster.
+func (s *metricsStore) Reset() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage = nil
+	s.resources = nil
+	s.polled = false
+	s.unavailable = false
+}
+
+// usageOf returns the latest metrics for the given object, or false if
+// there are none.
+func (s *metricsStore) usageOf(key string) (resourceUsage, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	usage := s.usage[key]
+	return usage, usage.Measured
+}
+
+// value returns the cell value of the column for the given object.
+func (s *metricsStore) value(key string, col metricsColumn) any {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.unavailable {
+		return "n/a"
+	}
+	if !s.polled {
+		return ""
+	}
+	usage := s.usage[key]
+	if !usage.Measured {
+		return "-"
+	}
+	resources := s.resources[key]
+	usage.CPURequest = resources.CPURequest
+	usage.CPULimit = resources.CPULimit
+	usage.MemoryRequest = resources.MemoryRequest
+	usage.MemoryLimit = resources.MemoryLimit
+	usage.CPUAllocatable = resources.CPUAllocatable
+	usage.MemoryAllocatable = resources.MemoryAllocatable
+	return col.Value(usage)
+}
+
+// resourcesOf returns the requests and limits summed up from a pod's
+// containers, or the allocatable resources of a node.
+func resourcesOf(gvr schema.GroupVersionResource, obj map[string]any) resourceUsage {
+	var u resourceUsage
+	switch gvr.Resource {
+	case "pods":
+		containers, _, _ := unstructured.NestedSlice(obj, "spec", "containers")
+		for _, c := range containers {
+			container, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			requests, _, _ := unstructured.NestedStringMap(container, "resources", "requests")
+			limits, _, _ := unstructured.NestedStringMap(container, "resources", "limits")
+			cpu, memory := parseResourceList(requests)
+			u.CPURequest += cpu
+			u.MemoryRequest += memory
+			cpu, memory = parseResourceList(limits)
+			u.CPULimit += cpu
+			u.MemoryLimit += memory
+		}
+	case "nodes":
+		allocatable, _, _ := unstructured.NestedStringMap(obj, "status", "allocatable")
+		u.CPUAllocatable, u.MemoryAllocatable = parseResourceList(allocatable)
+	}
+	return u
+}
+
+// Column returns a cell that always shows the latest metrics for the given
+// object. Deleted objects get the current value without any styling, so it
+// doesn't change or get colored on a grayed-out row.
+func (s *metricsStore) Column(key string, col metricsColumn, deleted bool) any {
+	if deleted {
+		value := s.value(key, col)
+		if styled, ok := value.(table.StyledColumn); ok {
+			return styled.Value
+		}
+		return value
+	}
+	return table.DynamicColumn{
+		Value: func() any { return s.value(key, col) },
+	}
+}
+
+// metricsPoller periodically polls the metrics.k8s.io API for pod or node
+// metrics, and updates the [metricsStore] with them. Only the metrics are
+// listed, as the pods and nodes themselves are already being watched.
+type metricsPoller struct {
+	Clients  *kubeClients
+	Store    *metricsStore
+	Interval time.Duration
+	OnError  func(error)
+
+	Nodes bool
+	// Namespace to poll pods in, or empty for all namespaces.
+	Namespace     string
+	LabelSelector string
+}
+
+func (m *metricsPoller) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+	for {
+		if err := m.poll(ctx); err != nil && ctx.Err() == nil && m.OnError != nil {
+			m.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *metricsPoller) poll(ctx context.Context) error {
+	var (
+		usage map[string]resourceUsage
+		err   error
+	)
+	if m.Nodes {
+		usage, err = m.pollNodes(ctx)
+	} else {
+		usage, err = m.pollPods(ctx)
+	}
+	if isMetricsUnavailable(err) {
+		m.Store.setUnavailable()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	m.Store.set(usage)
+	return nil
+}
+
+// isMetricsUnavailable returns true if the error is because metrics-server
+// isn't installed or isn't running.
+func isMetricsUnavailable(err error) bool {
+	return apierrors.IsNotFound(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		meta.IsNoMatchError(err)
+}
+
+func (m *metricsPoller) pollPods(ctx context.Context) (map[string]resourceUsage, error) {
+	opts := metav1.ListOptions{LabelSelector: m.LabelSelector}
+	metricsList, err := m.Clients.Dynamic.Resource(gvrPodMetrics).Namespace(m.Namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	usage := make(map[string]resourceUsage, len(metricsList.Items))
+	for _, item := range metricsList.Items {
+		var u resourceUsage
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		for _, c := range containers {
+			container, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			cpu, memory := parseMetricsUsage(container)
+			u.CPU += cpu
+			u.Memory += memory
+		}
+		u.Measured = true
+		usage[metricsKey(item.GetNamespace(), item.GetName())] = u
+	}
+	return usage, nil
+}
+
+func (m *metricsPoller) pollNodes(ctx context.Context) (map[string]resourceUsage, error) {
+	opts := metav1.ListOptions{LabelSelector: m.LabelSelector}
+	metricsList, err := m.Clients.Dynamic.Resource(gvrNodeMetrics).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	usage := make(map[string]resourceUsage, len(metricsList.Items))
+	for _, item := range metricsList.Items {
+		var u resourceUsage
+		u.CPU, u.Memory = parseMetricsUsage(item.Object)
+		u.Measured = true
+		usage[metricsKey("", item.GetName())] = u
+	}
+	return usage, nil
+}
+
+// parseMetricsUsage parses the "usage" field found in both node metrics and
+// in the containers of pod metrics.
+func parseMetricsUsage(obj map[string]any) (milliCPU, memoryBytes int64) {
+	usage, _, _ := unstructured.NestedStringMap(obj, "usage")
+	return parseResourceList(usage)
+}
+
+// parseResourceList parses the CPU and memory quantities of a resource list,
+// such as a container's requests.
+func parseResourceList(list map[string]string) (milliCPU, memoryBytes int64) {
+	if q, err := apiresource.ParseQuantity(list[string(corev1.ResourceCPU)]); err == nil {
+		milliCPU = q.MilliValue()
+	}
+	if q, err := apiresource.ParseQuantity(list[string(corev1.ResourceMemory)]); err == nil {
+		memoryBytes = q.Value()
+	}
+	return milliCPU, memoryBytes
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func newTestMetricsClients(metrics []*unstructured.Unstructured) *kubeClients {
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme, map[schema.GroupVersionResource]string{
+		gvrPodMetrics:  "PodMetricsList",
+		gvrNodeMetrics: "NodeMetricsList",
+	})
+	for _, obj := range metrics {
+		dyn.Tracker().Create(gvrPodMetrics, obj, obj.GetNamespace())
+	}
+	return &kubeClients{Dynamic: dyn}
+}
+
+func newTestPodMetrics(namespace, name string, usages ...map[string]any) *unstructured.Unstructured {
+	var containers []any
+	for _, usage := range usages {
+		containers = append(containers, map[string]any{"usage": usage})
+	}
+	obj := &unstructured.Unstructured{Object: map[string]any{"containers": containers}}
+	obj.SetAPIVersion("metrics.k8s.io/v1beta1")
+	obj.SetKind("PodMetrics")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestMetricsPollerPods(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-pod"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("100Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("200m"),
+				},
+			}},
+			{},
+		}},
+	}
+	clients := newTestMetricsClients([]*unstructured.Unstructured{
+		newTestPodMetrics("default", "my-pod",
+			map[string]any{"cpu": "50m", "memory": "60Mi"},
+			map[string]any{"cpu": "45m", "memory": "30Mi"}),
+	})
+	store := &metricsStore{}
+	poller := &metricsPoller{Clients: clients, Store: store, Namespace: "default"}
+
+	// The requests and limits come from the watched pods
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetResources(metricsKey("default", "my-pod"), resourcesOf(gvrPods, obj))
+
+	if got := store.value(metricsKey("default", "my-pod"), podMetricsColumns[0]); got != "" {
+		t.Errorf("want empty value before polling, got %v", got)
+	}
+	if err := poller.poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		column string
+		want   string
+	}{
+		{name: "my-pod", column: "CPU", want: "95m"},
+		{name: "my-pod", column: "MEM", want: "90Mi"},
+		{name: "my-pod", column: "%CPU/R", want: "95%"},
+		{name: "my-pod", column: "%CPU/L", want: "47%"},
+		{name: "my-pod", column: "%MEM/R", want: "90%"},
+		{name: "my-pod", column: "%MEM/L", want: "-"},
+		{name: "new-pod", column: "CPU", want: "-"},
+	}
+	for _, test := range tests {
+		t.Run(test.name+"/"+test.column, func(t *testing.T) {
+			var col metricsColumn
+			for _, c := range podMetricsColumns {
+				if c.Header == test.column {
+					col = c
+				}
+			}
+			row := table.Row{Fields: []any{store.Column(metricsKey("default", test.name), col, false)}}
+			if got := row.PlainFields()[0]; got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMetricsPollerUnavailable(t *testing.T) {
+	clients := newTestMetricsClients(nil)
+	clients.Dynamic.(*dynamicfake.FakeDynamicClient).PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(gvrNodeMetrics.GroupResource(), "")
+	})
+	store := &metricsStore{}
+	poller := &metricsPoller{Clients: clients, Store: store, Nodes: true}
+
+	if err := poller.poll(context.Background()); err != nil {
+		t.Fatalf("want no error when metrics-server is missing, got: %v", err)
+	}
+	if got := store.value(metricsKey("", "my-node"), nodeMetricsColumns[0]); got != "n/a" {
+		t.Errorf("want %q, got %v", "n/a", got)
+	}
+}
+
+func TestMetricsPollerOnlyListsMetrics(t *testing.T) {
+	clients := newTestMetricsClients(nil)
+	fakeDyn := clients.Dynamic.(*dynamicfake.FakeDynamicClient)
+	poller := &metricsPoller{Clients: clients, Store: &metricsStore{}, Namespace: "default", LabelSelector: "app=web"}
+	if err := poller.poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range fakeDyn.Actions() {
+		list, ok := action.(k8stesting.ListAction)
+		if !ok {
+			t.Errorf("want only list actions, got %s", action.GetVerb())
+			continue
+		}
+		if got := list.GetResource(); got != gvrPodMetrics {
+			t.Errorf("want only %s to be listed, got %s", gvrPodMetrics, got)
+		}
+		if got, want := list.GetListRestrictions().Labels.String(), "app=web"; got != want {
+			t.Errorf("want label selector %q, got %q", want, got)
+		}
+	}
+}
+
+func TestResourcesOfNode(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-node"},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+		}},
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := resourcesOf(gvrNodes, obj)
+	if got.CPUAllocatable != 4000 || got.MemoryAllocatable != 8*1024*1024*1024 {
+		t.Errorf("want 4000m CPU and 8Gi memory allocatable, got %dm and %d bytes", got.CPUAllocatable, got.MemoryAllocatable)
+	}
+}
+
+func TestMetricsStoreResources(t *testing.T) {
+	store := &metricsStore{}
+	store.set(map[string]resourceUsage{
+		metricsKey("default", "my-pod"): {Measured: true, CPU: 50},
+	})
+	key := metricsKey("default", "my-pod")
+	store.SetResources(key, resourceUsage{CPURequest: 100})
+	if got := store.value(key, podMetricsColumns[2]); got.(table.StyledColumn).Value != "50%" {
+		t.Errorf("want %q, got %v", "50%", got)
+	}
+	store.Forget(key)
+	if got := store.value(key, podMetricsColumns[2]); got != "-" {
+		t.Errorf("want %q after forgetting the pod, got %v", "-", got)
+	}
+}
@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+// NotifyRule is a condition that triggers a notification.
+type NotifyRule string
+
+const (
+	// NotifyOnError notifies when a row enters an error status,
+	// such as "CrashLoopBackOff".
+	NotifyOnError NotifyRule = "error"
+	// NotifyOnReady notifies when all rows' READY fractions are complete,
+	// such as "1/1".
+	NotifyOnReady NotifyRule = "ready"
+	// NotifyOnDeleted notifies when a row is deleted.
+	NotifyOnDeleted NotifyRule = "deleted"
+)
+
+// NotifyRules are all supported notification rules.
+var NotifyRules = []NotifyRule{NotifyOnError, NotifyOnReady, NotifyOnDeleted}
+
+// NotifyMethod is a way of delivering a notification to the terminal.
+type NotifyMethod string
+
+const (
+	// NotifyBell rings the terminal bell.
+	NotifyBell NotifyMethod = "bell"
+	// NotifyOSC9 sends a desktop notification using the OSC 9 escape
+	// sequence, supported by e.g iTerm2, Windows Terminal and kitty.
+	NotifyOSC9 NotifyMethod = "osc9"
+	// NotifyOSC777 sends a desktop notification using the OSC 777 escape
+	// sequence, supported by e.g urxvt and foot.
+	NotifyOSC777 NotifyMethod = "osc777"
+)
+
+// NotifyMethods are all supported notification methods.
+var NotifyMethods = []NotifyMethod{NotifyBell, NotifyOSC9, NotifyOSC777}
+
+// notifyCommandTimeout is the maximum time the notification command may
+// run before it is killed.
+const notifyCommandTimeout = 30 * time.Second
+
+// NotifyEvent is the event that triggered a notification. It is passed
+// as JSON on stdin to the notification command.
+type NotifyEvent struct {
+	Rule          NotifyRule `json:"rule"`
+	Message       string     `json:"message"`
+	Kind          string     `json:"kind,omitempty"`
+	Namespace     string     `json:"namespace,omitempty"`
+	Name          string     `json:"name,omitempty"`
+	State         string     `json:"state,omitempty"`
+	PreviousState string     `json:"previousState,omitempty"`
+	Time          time.Time  `json:"time"`
+}
+
+// Notifier sends notifications when rows change in ways that match
+// any of its rules.
+//
+// Rows are only tracked until [Notifier.Arm] is called, so that the
+// initial list of resources does not cause a burst of notifications.
+type Notifier struct {
+	Rules   []NotifyRule
+	Methods []NotifyMethod
+	// Command, if set, is run through the shell for every notification,
+	// with the [NotifyEvent] as JSON on stdin.
+	Command string
+	// Output is where the terminal escape sequences are written to.
+	Output io.Writer
+	// OnError is called when the notification command fails.
+	OnError func(err error)
+
+	mu       sync.Mutex
+	armed    bool
+	states   map[string]string
+	ready    map[string]bool
+	allReady bool
+}
+
+func (o Options) newNotifier() (*Notifier, error) {
+	if len(o.Notify) == 0 {
+		return nil, nil
+	}
+	n := &Notifier{
+		Command: o.NotifyCommand,
+		Output:  os.Stderr,
+	}
+	for _, rule := range o.Notify {
+		if !slices.Contains(NotifyRules, NotifyRule(rule)) {
+			return nil, fmt.Errorf("unknown notify rule: %q, allowed rules are: %s", rule, NotifyRules)
+		}
+		n.Rules = append(n.Rules, NotifyRule(rule))
+	}
+	for _, method := range o.NotifyVia {
+		if !slices.Contains(NotifyMethods, NotifyMethod(method)) {
+			return nil, fmt.Errorf("unknown notify method: %q, allowed methods are: %s", method, NotifyMethods)
+		}
+		n.Methods = append(n.Methods, NotifyMethod(method))
+	}
+	return n, nil
+}
+
+// Arm enables the notifications. Any changes seen before this are only
+// used as the baseline.
+func (n *Notifier) Arm() {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.armed = true
+}
+
+// Reset forgets all tracked rows and disables notifications until
+// [Notifier.Arm] is called again, such as when the watch is restarted.
+func (n *Notifier) Reset() {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.armed = false
+	n.states = nil
+	n.ready = nil
+	n.allReady = false
+}
+
+// Observe is called for every row added to the table. The ready fraction
+// is nil if the row does not have a READY column.
+func (n *Notifier) Observe(row table.Row, eventType watch.EventType, ready *Fraction) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.states == nil {
+		n.states = map[string]string{}
+		n.ready = map[string]bool{}
+	}
+
+	prevState, seen := n.states[row.ID]
+	event := NotifyEvent{
+		State:         row.State,
+		PreviousState: prevState,
+		Time:          time.Now(),
+	}
+	resourceName := "rows"
+	if obj, ok := rowObjectOf(row); ok {
+		event.Kind = obj.GVK.Kind
+		event.Namespace = obj.Object.GetNamespace()
+		event.Name = obj.Object.GetName()
+		resourceName = obj.Resource.Resource
+	}
+
+	if eventType == watch.Deleted {
+		delete(n.states, row.ID)
+		delete(n.ready, row.ID)
+		if seen && n.hasRule(NotifyOnDeleted) {
+			event.Rule = NotifyOnDeleted
+			event.Message = fmt.Sprintf("%s was deleted", event.ref())
+			n.notify(event)
+		}
+	} else {
+		n.states[row.ID] = row.State
+		if n.hasRule(NotifyOnError) && isErrorState(row.State) && !isErrorState(prevState) {
+			event.Rule = NotifyOnError
+			event.Message = fmt.Sprintf("%s: %s", event.ref(), row.State)
+			n.notify(event)
+		}
+		if ready != nil {
+			n.ready[row.ID] = ready.Count >= ready.Total
+		}
+	}
+
+	allReady := len(n.ready) > 0
+	for _, isReady := range n.ready {
+		allReady = allReady && isReady
+	}
+	if allReady && !n.allReady && n.hasRule(NotifyOnReady) {
+		n.notify(NotifyEvent{
+			Rule:    NotifyOnReady,
+			Message: fmt.Sprintf("All %d %s are ready", len(n.ready), resourceName),
+			Kind:    event.Kind,
+			Time:    event.Time,
+		})
+	}
+	n.allReady = allReady
+}
+
+func (e NotifyEvent) ref() string {
+	if e.Kind == "" {
+		return e.Name
+	}
+	return fmt.Sprintf("%s/%s", strings.ToLower(e.Kind), e.Name)
+}
+
+// isErrorState returns true if any of the comma-separated statuses
+// are colored as errors.
+func isErrorState(state string) bool {
+	for s := range strings.SplitSeq(state, ",") {
+		if StatusLevelOf(s) == StatusLevelError {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Notifier) hasRule(rule NotifyRule) bool {
+	return slices.Contains(n.Rules, rule)
+}
+
+func (n *Notifier) notify(event NotifyEvent) {
+	if !n.armed {
+		return
+	}
+	// Strip control characters, as they could end the escape sequence
+	message := strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, event.Message)
+	for _, method := range n.Methods {
+		switch method {
+		case NotifyBell:
+			io.WriteString(n.Output, "\a")
+		case NotifyOSC9:
+			fmt.Fprintf(n.Output, "\x1b]9;klock: %s\a", message)
+		case NotifyOSC777:
+			fmt.Fprintf(n.Output, "\x1b]777;notify;klock;%s\a", message)
+		}
+	}
+	if n.Command != "" {
+		go n.runCommand(event)
+	}
+}
+
+func (n *Notifier) runCommand(event NotifyEvent) {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		n.onError(err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notifyCommandTimeout)
+	defer cancel()
+	cmd := shellCommand(ctx, n.Command)
+	cmd.Stdin = bytes.NewReader(eventJSON)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if len(out) > 0 {
+			err = fmt.Errorf("%w: %s", err, bytes.TrimSpace(out))
+		}
+		n.onError(fmt.Errorf("notify command: %w", err))
+	}
+}
+
+func (n *Notifier) onError(err error) {
+	if n.OnError != nil {
+		n.OnError(err)
+	}
+}
+
+// shellCommand returns a command that runs the command line through
+// the system's shell.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	// Don't wait for any child processes that still hold on to the
+	// output after the shell was killed
+	cmd.WaitDelay = time.Second
+	return cmd
+}
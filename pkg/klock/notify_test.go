@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func newTestNotifyRow(name, state string) table.Row {
+	return table.Row{
+		ID:     name,
+		State:  state,
+		Object: newTestRowObject(gvrPods, "Pod", "default", name),
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	n := &Notifier{
+		Rules:   []NotifyRule{NotifyOnError, NotifyOnReady, NotifyOnDeleted},
+		Methods: []NotifyMethod{NotifyOSC9},
+		Output:  &buf,
+	}
+	notReady := &Fraction{Count: 0, Total: 1}
+	ready := &Fraction{Count: 1, Total: 1}
+
+	// Initial list should not notify, even for errors
+	n.Observe(newTestNotifyRow("pod-a", "CrashLoopBackOff"), watch.Added, notReady)
+	n.Observe(newTestNotifyRow("pod-b", "Running"), watch.Added, ready)
+	n.Arm()
+	if buf.Len() > 0 {
+		t.Fatalf("want no notifications before armed, got: %q", buf.String())
+	}
+
+	tests := []struct {
+		name      string
+		row       table.Row
+		eventType watch.EventType
+		ready     *Fraction
+		want      string
+	}{
+		{
+			name:      "still in error",
+			row:       newTestNotifyRow("pod-a", "Error"),
+			eventType: watch.Modified,
+			ready:     notReady,
+			want:      "",
+		},
+		{
+			name:      "enters error",
+			row:       newTestNotifyRow("pod-b", "Running,OOMKilled"),
+			eventType: watch.Modified,
+			ready:     notReady,
+			want:      "\x1b]9;klock: pod/pod-b: Running,OOMKilled\a",
+		},
+		{
+			name:      "some ready",
+			row:       newTestNotifyRow("pod-a", "Running"),
+			eventType: watch.Modified,
+			ready:     ready,
+			want:      "",
+		},
+		{
+			name:      "all ready",
+			row:       newTestNotifyRow("pod-b", "Running"),
+			eventType: watch.Modified,
+			ready:     ready,
+			want:      "\x1b]9;klock: All 2 pods are ready\a",
+		},
+		{
+			name:      "deleted",
+			row:       newTestNotifyRow("pod-b", "Deleted"),
+			eventType: watch.Deleted,
+			ready:     ready,
+			want:      "\x1b]9;klock: pod/pod-b was deleted\a",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf.Reset()
+			n.Observe(test.row, test.eventType, test.ready)
+			if got := buf.String(); got != test.want {
+				t.Errorf("wrong notification\nwant: %q\ngot:  %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestNotifierCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a POSIX shell")
+	}
+	file := filepath.Join(t.TempDir(), "event.json")
+	errs := make(chan error, 1)
+	n := &Notifier{
+		Rules:   []NotifyRule{NotifyOnDeleted},
+		Command: "cat > " + file,
+		Output:  &bytes.Buffer{},
+		OnError: func(err error) { errs <- err },
+	}
+	n.runCommand(NotifyEvent{Rule: NotifyOnDeleted, Name: "pod-a", Time: time.Now()})
+
+	select {
+	case err := <-errs:
+		t.Fatal(err)
+	default:
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event NotifyEvent
+	if err := json.Unmarshal(content, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Rule != NotifyOnDeleted || event.Name != "pod-a" {
+		t.Errorf("wrong event: %+v", event)
+	}
+}
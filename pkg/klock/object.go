@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+// rowObject is stored in [table.Row.Object] for every row added by the
+// [Printer], so panes and actions know which resource a row refers to.
+type rowObject struct {
+	GVK        schema.GroupVersionKind
+	Resource   schema.GroupVersionResource
+	Namespaced bool
+	// Object is the object from the server-side table row. Note that it
+	// may only contain the object's metadata.
+	Object *unstructured.Unstructured
+	// Context is the kubeconfig context that the object is from, when
+	// watching multiple contexts, and ConfigFlags are the flags for
+	// connecting to it.
+	Context     string
+	ConfigFlags *genericclioptions.ConfigFlags
+}
+
+func rowObjectOf(row table.Row) (*rowObject, bool) {
+	obj, ok := row.Object.(*rowObject)
+	return obj, ok && obj != nil && obj.Object != nil
+}
+
+// Is returns true if the object is of the given API group and kind.
+func (o *rowObject) Is(group, kind string) bool {
+	return o.GVK.Group == group && o.GVK.Kind == kind
+}
+
+// configFlagsOr returns the config flags for the object's context,
+// or the fallback flags if it's from the only context being watched.
+func (o *rowObject) configFlagsOr(fallback *genericclioptions.ConfigFlags) *genericclioptions.ConfigFlags {
+	if o.ConfigFlags != nil {
+		return o.ConfigFlags
+	}
+	return fallback
+}
+
+func newClientset(configFlags *genericclioptions.ConfigFlags) (kubernetes.Interface, error) {
+	restConfig, err := configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SparklineSource is the value that is shown as a sparkline in the
+// TREND column.
+type SparklineSource string
+
+const (
+	SparklineRestarts SparklineSource = "restarts"
+	SparklineReady    SparklineSource = "ready"
+	SparklineCPU      SparklineSource = "cpu"
+	SparklineMemory   SparklineSource = "memory"
+)
+
+// SparklineSources are all supported sparkline sources.
+var SparklineSources = []SparklineSource{
+	SparklineRestarts,
+	SparklineReady,
+	SparklineCPU,
+	SparklineMemory,
+}
+
+// sparklineAvailable returns true if the sparkline's source value exists
+// for the current resource type.
+func (p *Printer) sparklineAvailable(colDefs []metav1.TableColumnDefinition) bool {
+	switch p.Sparkline {
+	case SparklineRestarts, SparklineReady:
+		for _, colDef := range colDefs {
+			if strings.EqualFold(colDef.Name, string(p.Sparkline)) && (colDef.Priority == 0 || p.WideOutput) {
+				return true
+			}
+		}
+		return false
+	case SparklineCPU, SparklineMemory:
+		return len(p.metricsColumns) > 0
+	default:
+		return false
+	}
+}
+
+// sparklineSample returns the function used to sample the sparkline's
+// value for a row. Metrics are looked up on every sample, while the other
+// sources are only updated on watch events.
+func (p *Printer) sparklineSample(metricsKey string, ready *Fraction, restarts *float64) func() (float64, bool) {
+	switch p.Sparkline {
+	case SparklineRestarts:
+		return func() (float64, bool) {
+			if restarts == nil {
+				return 0, false
+			}
+			return *restarts, true
+		}
+	case SparklineReady:
+		return func() (float64, bool) {
+			if ready == nil {
+				return 0, false
+			}
+			return float64(ready.Count), true
+		}
+	case SparklineCPU:
+		return func() (float64, bool) {
+			usage, ok := p.Metrics.usageOf(metricsKey)
+			return float64(usage.CPU), ok
+		}
+	case SparklineMemory:
+		return func() (float64, bool) {
+			usage, ok := p.Metrics.usageOf(metricsKey)
+			return float64(usage.Memory), ok
+		}
+	default:
+		return nil
+	}
+}
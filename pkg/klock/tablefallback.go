@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/kubectl/pkg/cmd/get"
+)
+
+var gvrCustomResourceDefinitions = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// tableFallback builds tables client-side, for when the server responds
+// with plain objects instead of tables, such as some aggregated APIs or
+// proxies that don't support server-side printing.
+type tableFallback struct {
+	Clients *kubeClients
+	GVR     schema.GroupVersionResource
+
+	once    sync.Once
+	columns []printerColumn
+}
+
+// printerColumn is a column from a CRD's additionalPrinterColumns.
+type printerColumn struct {
+	Definition metav1.TableColumnDefinition
+	Path       *jsonpath.JSONPath
+}
+
+// Table converts a plain object into a table, with a NAME column followed by
+// the CRD's additionalPrinterColumns, or by an AGE column if there are none.
+func (f *tableFallback) Table(obj runtime.Object) (*metav1.Table, error) {
+	unstrucObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("%w: want *unstructured.Unstructured, got %T", errNotTable, obj)
+	}
+	f.once.Do(f.loadColumns)
+
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name"},
+		},
+	}
+	row := metav1.TableRow{
+		Cells:  []any{unstrucObj.GetName()},
+		Object: runtime.RawExtension{Object: unstrucObj},
+	}
+	if len(f.columns) == 0 {
+		table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{Name: "Age", Type: "date"})
+		row.Cells = append(row.Cells, unstrucObj.GetCreationTimestamp().Time)
+	}
+	for _, col := range f.columns {
+		table.ColumnDefinitions = append(table.ColumnDefinitions, col.Definition)
+		row.Cells = append(row.Cells, col.Value(unstrucObj.Object))
+	}
+	table.Rows = []metav1.TableRow{row}
+	return table, nil
+}
+
+// loadColumns fetches the additionalPrinterColumns from the resource's CRD.
+// Built-in resources don't have a CRD, and any other errors are ignored
+// as well, as it then falls back to only showing NAME and AGE.
+func (f *tableFallback) loadColumns() {
+	if f.Clients == nil || f.GVR.Group == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	crdName := f.GVR.Resource + "." + f.GVR.Group
+	obj, err := f.Clients.Dynamic.Resource(gvrCustomResourceDefinitions).Get(ctx, crdName, metav1.GetOptions{})
+	if err != nil {
+		return
+	}
+	f.columns = crdPrinterColumns(obj.Object, f.GVR.Version)
+}
+
+// crdPrinterColumns returns the additionalPrinterColumns of the given version
+// in a CustomResourceDefinition object.
+func crdPrinterColumns(crd map[string]any, version string) []printerColumn {
+	versions, _, _ := unstructured.NestedSlice(crd, "spec", "versions")
+	var columns []printerColumn
+	for _, v := range versions {
+		versionFields, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(versionFields, "name"); name != version {
+			continue
+		}
+		cols, _, _ := unstructured.NestedSlice(versionFields, "additionalPrinterColumns")
+		for _, c := range cols {
+			colFields, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			var def metav1.TableColumnDefinition
+			def.Name, _, _ = unstructured.NestedString(colFields, "name")
+			def.Type, _, _ = unstructured.NestedString(colFields, "type")
+			def.Format, _, _ = unstructured.NestedString(colFields, "format")
+			def.Description, _, _ = unstructured.NestedString(colFields, "description")
+			priority, _, _ := unstructured.NestedInt64(colFields, "priority")
+			def.Priority = int32(priority)
+			jsonPath, _, _ := unstructured.NestedString(colFields, "jsonPath")
+			relaxed, err := get.RelaxedJSONPathExpression(jsonPath)
+			if err != nil {
+				continue
+			}
+			path := jsonpath.New(def.Name).AllowMissingKeys(true)
+			if err := path.Parse(relaxed); err != nil {
+				continue
+			}
+			columns = append(columns, printerColumn{Definition: def, Path: path})
+		}
+	}
+	return columns
+}
+
+// Value evaluates the column on the object, formatted the same way as
+// the server does, except that dates are returned as [time.Time] so they are
+// shown as live ages or countdowns, like the server-side dates.
+func (c printerColumn) Value(obj map[string]any) any {
+	results, err := c.Path.FindResults(obj)
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		return "<none>"
+	}
+	var values []string
+	for _, value := range results[0] {
+		if !value.IsValid() || !value.CanInterface() {
+			continue
+		}
+		values = append(values, fmt.Sprint(value.Interface()))
+	}
+	if len(values) == 0 {
+		return "<none>"
+	}
+	if len(values) == 1 && c.Definition.Type == "date" {
+		if t, err := time.Parse(time.RFC3339, values[0]); err == nil {
+			return t
+		}
+	}
+	return strings.Join(values, ",")
+}
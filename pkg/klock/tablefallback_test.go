@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+var gvrWidgets = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+func newTestWidget(name string, size int64) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{"size": size},
+	}}
+	obj.SetAPIVersion("example.com/v1")
+	obj.SetKind("Widget")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetUID(types.UID("uid-" + name))
+	obj.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-time.Hour)))
+	return obj
+}
+
+func newTestWidgetCRD() *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"versions": []any{
+				map[string]any{
+					"name": "v1",
+					"additionalPrinterColumns": []any{
+						map[string]any{"name": "Size", "type": "integer", "jsonPath": ".spec.size"},
+						map[string]any{"name": "Color", "type": "string", "jsonPath": ".spec.color"},
+						map[string]any{"name": "Age", "type": "date", "jsonPath": ".metadata.creationTimestamp"},
+						map[string]any{"name": "Expires", "type": "date", "jsonPath": ".status.expiresAt"},
+					},
+				},
+			},
+		},
+	}}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName("widgets.example.com")
+	return crd
+}
+
+func newTestFallbackClients(objects ...*unstructured.Unstructured) *kubeClients {
+	dyn := dynamicfake.NewSimpleDynamicClient(scheme.Scheme)
+	for _, obj := range objects {
+		dyn.Tracker().Create(gvrCustomResourceDefinitions, obj, "")
+	}
+	return &kubeClients{Typed: fake.NewClientset(), Dynamic: dyn}
+}
+
+func columnNames(t *metav1.Table) []string {
+	var names []string
+	for _, col := range t.ColumnDefinitions {
+		names = append(names, col.Name)
+	}
+	return names
+}
+
+func TestTableFallbackPrinterColumns(t *testing.T) {
+	f := &tableFallback{Clients: newTestFallbackClients(newTestWidgetCRD()), GVR: gvrWidgets}
+	widget := newTestWidget("my-widget", 3)
+	expiresAt := time.Now().Add(3 * 24 * time.Hour).Truncate(time.Second)
+	unstructured.SetNestedField(widget.Object, expiresAt.Format(time.RFC3339), "status", "expiresAt")
+	objTable, err := f.Table(widget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := columnNames(objTable), []string{"Name", "Size", "Color", "Age", "Expires"}; !slices.Equal(got, want) {
+		t.Errorf("wrong columns\nwant: %q\ngot:  %q", want, got)
+	}
+	cells := objTable.Rows[0].Cells
+	if got, want := cells[:3], []any{"my-widget", "3", "<none>"}; !slices.Equal(got, want) {
+		t.Errorf("wrong cells\nwant: %q\ngot:  %q", want, got)
+	}
+	// Dates are kept as times, so they age like server-side tables
+	if got, ok := cells[3].(time.Time); !ok || !got.Equal(widget.GetCreationTimestamp().Time) {
+		t.Errorf("wrong age cell\nwant: %v\ngot:  %v", widget.GetCreationTimestamp(), cells[3])
+	}
+	if got, ok := cells[4].(time.Time); !ok || !got.Equal(expiresAt) {
+		t.Errorf("wrong expires cell\nwant: %v\ngot:  %v", expiresAt, cells[4])
+	}
+}
+
+func TestTableFallbackNoCRD(t *testing.T) {
+	f := &tableFallback{Clients: newTestFallbackClients(), GVR: gvrWidgets}
+	objTable, err := f.Table(newTestWidget("my-widget", 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := columnNames(objTable), []string{"Name", "Age"}; !slices.Equal(got, want) {
+		t.Errorf("wrong columns\nwant: %q\ngot:  %q", want, got)
+	}
+	if _, ok := objTable.Rows[0].Cells[1].(time.Time); !ok {
+		t.Errorf("want age as time.Time, got %T", objTable.Rows[0].Cells[1])
+	}
+}
+
+func TestPrintObjFallback(t *testing.T) {
+	p := Printer{
+		Table:    table.New(),
+		fallback: &tableFallback{Clients: newTestFallbackClients(newTestWidgetCRD()), GVR: gvrWidgets},
+	}
+	if _, err := p.PrintObj(newTestWidget("my-widget", 3), watch.Added); err != nil {
+		t.Fatal(err)
+	}
+	row, ok := p.Table.SelectedRow()
+	if !ok {
+		t.Fatal("want a row")
+	}
+	if got, want := row.PlainFields()[:3], []string{"my-widget", "3", "<none>"}; !slices.Equal(got, want) {
+		t.Errorf("wrong fields\nwant: %q\ngot:  %q", want, got)
+	}
+}
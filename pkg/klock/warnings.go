@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+// warningHandler shows the warnings sent by the API server, such as when
+// using a deprecated API version or violating a PodSecurity policy.
+// Each warning is only shown once.
+type warningHandler struct {
+	// Table shows the warnings in a banner. If nil, then the warnings are
+	// written to Output instead.
+	Table  *table.Model
+	Output io.Writer
+
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+// WrapConfigFn returns a function for [genericclioptions.ConfigFlags.WrapConfigFn]
+// that makes all clients report their warnings to the handler, prefixed with
+// the context name if non-empty.
+func (h *warningHandler) WrapConfigFn(wrap func(*rest.Config) *rest.Config, context string) func(*rest.Config) *rest.Config {
+	return func(config *rest.Config) *rest.Config {
+		if wrap != nil {
+			config = wrap(config)
+		}
+		config.WarningHandler = contextWarningHandler{handler: h, context: context}
+		config.WarningHandlerWithContext = nil
+		return config
+	}
+}
+
+// HandleWarningHeader implements [rest.WarningHandler].
+func (h *warningHandler) HandleWarningHeader(code int, agent string, text string) {
+	if code != 299 || text == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.seen[text]; ok {
+		return
+	}
+	if h.seen == nil {
+		h.seen = map[string]struct{}{}
+	}
+	h.seen[text] = struct{}{}
+
+	if h.Table != nil {
+		h.Table.ShowBanner(text)
+	} else {
+		fmt.Fprintf(h.Output, "Warning: %s\n", text)
+	}
+}
+
+type contextWarningHandler struct {
+	handler *warningHandler
+	context string
+}
+
+// HandleWarningHeader implements [rest.WarningHandler].
+func (h contextWarningHandler) HandleWarningHeader(code int, agent string, text string) {
+	if h.context != "" && text != "" {
+		text = h.context + ": " + text
+	}
+	h.handler.HandleWarningHeader(code, agent, text)
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestWarningHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := &warningHandler{Output: &buf}
+	wrap := h.WrapConfigFn(nil, "")
+	wrapProd := h.WrapConfigFn(nil, "prod")
+
+	handler := wrap(&rest.Config{}).WarningHandler
+	handler.HandleWarningHeader(299, "", "v1 ComponentStatus is deprecated")
+	handler.HandleWarningHeader(299, "", "v1 ComponentStatus is deprecated")
+	handler.HandleWarningHeader(199, "", "miscellaneous warning")
+	handler.HandleWarningHeader(299, "", "")
+	wrapProd(&rest.Config{}).WarningHandler.HandleWarningHeader(299, "", "v1 ComponentStatus is deprecated")
+
+	want := []string{
+		"Warning: v1 ComponentStatus is deprecated",
+		"Warning: prod: v1 ComponentStatus is deprecated",
+	}
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("want:\n%s\ngot:\n%s", strings.Join(want, "\n"), buf.String())
+	}
+}
+
+func TestWarningHandlerWrapsConfig(t *testing.T) {
+	h := &warningHandler{}
+	wrapped := false
+	wrap := h.WrapConfigFn(func(config *rest.Config) *rest.Config {
+		wrapped = true
+		return config
+	}, "")
+	config := wrap(&rest.Config{})
+	if !wrapped {
+		t.Error("want previous WrapConfigFn to be called")
+	}
+	if config.WarningHandler == nil {
+		t.Error("want WarningHandler to be set")
+	}
+}
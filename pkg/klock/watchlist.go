@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/utils/ptr"
+)
+
+// defaultInitialEventsTimeout is how long to wait for the bookmark that ends
+// the initial events, before falling back to a list followed by a watch.
+// It's an overall deadline, as servers that ignore sendInitialEvents still
+// send regular events for busy resources.
+const defaultInitialEventsTimeout = 30 * time.Second
+
+// errInitialEventsTimeout is returned by [Watcher.pipeEvents] when the
+// bookmark that ends the initial events never arrived, such as when the
+// API server ignores the sendInitialEvents parameter.
+var errInitialEventsTimeout = errors.New("timed out waiting for the initial events to end")
+
+// canWatchList returns true if the arguments are a plain resource type,
+// and not specific object names, so the initial state can be streamed
+// using a single watch request.
+func (w *Watcher) canWatchList() bool {
+	return !w.noWatchList && len(w.Args) == 1 && !strings.Contains(w.Args[0], "/")
+}
+
+func (w *Watcher) initialEventsTimeoutOrDefault() time.Duration {
+	if w.initialEventsTimeout > 0 {
+		return w.initialEventsTimeout
+	}
+	return defaultInitialEventsTimeout
+}
+
+// watchList starts a watch that first sends the current state of the
+// resource as ADDED events, followed by a bookmark, as described in
+// https://kep.k8s.io/3157. Compared to a list followed by a watch this
+// avoids buffering the whole list in the API server's memory.
+func (w *Watcher) watchList(ns string, mapping *meta.RESTMapping, includeObject bool) (watch.Interface, error) {
+	client, err := cmdutil.NewFactory(w.ConfigFlags).UnstructuredClientForMapping(mapping)
+	if err != nil {
+		return nil, err
+	}
+	client = resource.NewClientWithOptions(client, func(req *rest.Request) {
+		transformRequests(req, includeObject)
+	})
+	if w.AllNamespaces {
+		ns = ""
+	}
+	apiVersion := mapping.GroupVersionKind.GroupVersion().String()
+	return resource.NewHelper(client, mapping).Watch(ns, apiVersion, &metav1.ListOptions{
+		LabelSelector:        w.LabelSelector,
+		FieldSelector:        w.FieldSelector,
+		SendInitialEvents:    ptr.To(true),
+		ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+		AllowWatchBookmarks:  true,
+	})
+}
+
+// isWatchListUnsupported returns true if the API server rejected the
+// watch request from [Watcher.watchList], such as when it's too old or has
+// the WatchList feature gate disabled, in which case it's safe to fall back
+// to a list followed by a watch.
+func isWatchListUnsupported(err error) bool {
+	return apierrors.IsBadRequest(err) ||
+		apierrors.IsInvalid(err) ||
+		apierrors.IsMethodNotSupported(err)
+}
+
+// isInitialEventsEnd returns true if the bookmark event marks the end of
+// the initial events sent by [Watcher.watchList].
+func isInitialEventsEnd(obj runtime.Object) bool {
+	if hasInitialEventsAnnotation(obj) {
+		return true
+	}
+	// When requesting tables, the bookmark object is in the table's row
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok || !isTable(obj) {
+		return false
+	}
+	rows, _, _ := unstructured.NestedSlice(u.Object, "rows")
+	for _, row := range rows {
+		rowFields, ok := row.(map[string]any)
+		if !ok {
+			continue
+		}
+		rowObj, _, _ := unstructured.NestedMap(rowFields, "object")
+		if hasInitialEventsAnnotation(&unstructured.Unstructured{Object: rowObj}) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasInitialEventsAnnotation(obj runtime.Object) bool {
+	accessor, err := meta.Accessor(obj)
+	return err == nil && accessor.GetAnnotations()[metav1.InitialEventsAnnotationKey] == "true"
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package klock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/applejag/kubectl-klock/pkg/table"
+)
+
+func TestCanWatchList(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{name: "resource type", args: []string{"pods"}, want: true},
+		{name: "named object", args: []string{"pods", "my-pod"}, want: false},
+		{name: "type/name", args: []string{"pods/my-pod"}, want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &Watcher{Args: tc.args}
+			if got := w.canWatchList(); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsWatchListUnsupported(t *testing.T) {
+	gr := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "bad request", err: apierrors.NewBadRequest("unknown parameter"), want: true},
+		{name: "invalid", err: apierrors.NewInvalid(schema.GroupKind{Kind: "ListOptions"}, "", nil), want: true},
+		{name: "forbidden", err: apierrors.NewForbidden(gr, "", errors.New("rbac")), want: false},
+		{name: "other", err: errors.New("connection refused"), want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isWatchListUnsupported(tc.err); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func newTestTableBookmark(annotations map[string]any) *unstructured.Unstructured {
+	metadata := map[string]any{"resourceVersion": "123"}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "meta.k8s.io/v1",
+		"kind":       "Table",
+		"metadata":   map[string]any{"resourceVersion": "123"},
+		"rows": []any{
+			map[string]any{
+				"cells": []any{""},
+				"object": map[string]any{
+					"apiVersion": "meta.k8s.io/v1",
+					"kind":       "PartialObjectMetadata",
+					"metadata":   metadata,
+				},
+			},
+		},
+	}}
+}
+
+func TestIsInitialEventsEnd(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  runtime.Object
+		want bool
+	}{
+		{
+			name: "annotated bookmark",
+			obj: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]any{
+					"resourceVersion": "123",
+					"annotations":     map[string]any{metav1.InitialEventsAnnotationKey: "true"},
+				},
+			}},
+			want: true,
+		},
+		{
+			name: "regular bookmark",
+			obj: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata":   map[string]any{"resourceVersion": "123"},
+			}},
+			want: false,
+		},
+		{
+			name: "table bookmark",
+			obj:  newTestTableBookmark(nil),
+			want: false,
+		},
+		{
+			name: "table with annotated bookmark",
+			obj:  newTestTableBookmark(map[string]any{metav1.InitialEventsAnnotationKey: "true"}),
+			want: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isInitialEventsEnd(tc.obj); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPipeEventsInitialEventsTimeout(t *testing.T) {
+	w := &Watcher{
+		Printer:              Printer{Table: table.New()},
+		initialEventsTimeout: 20 * time.Millisecond,
+	}
+	watcher := watch.NewFake()
+	defer watcher.Stop()
+
+	errChan := make(chan error, 1)
+	go func() { errChan <- w.pipeEvents(context.Background(), watcher, false) }()
+	select {
+	case err := <-errChan:
+		if !errors.Is(err, errInitialEventsTimeout) {
+			t.Errorf("want %v, got %v", errInitialEventsTimeout, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("want timeout when the initial events never end")
+	}
+}
+
+func TestPipeEventsInitialEventsTimeoutBusy(t *testing.T) {
+	w := &Watcher{
+		Printer: Printer{Table: table.New()},
+		Options: Options{
+			// Never flush, as there's no program to send the redraws to
+			CoalesceWindow: time.Hour,
+		},
+		initialEventsTimeout: 50 * time.Millisecond,
+	}
+	events := make(chan watch.Event)
+	done := make(chan struct{})
+	defer close(done)
+	// Events keep arriving, but the bookmark never does
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case events <- newTestEvent(watch.Modified, "uid-1", "Running"):
+				time.Sleep(5 * time.Millisecond)
+			}
+		}
+	}()
+
+	errChan := make(chan error, 1)
+	go func() { errChan <- w.pipeEvents(context.Background(), watch.NewProxyWatcher(events), false) }()
+	select {
+	case err := <-errChan:
+		if !errors.Is(err, errInitialEventsTimeout) {
+			t.Errorf("want %v, got %v", errInitialEventsTimeout, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("want timeout when events keep arriving without the bookmark")
+	}
+}
+
+func TestPipeEventsInitialEventsEnd(t *testing.T) {
+	w := &Watcher{
+		Printer:              Printer{Table: table.New()},
+		initialEventsTimeout: 20 * time.Millisecond,
+	}
+	watcher := watch.NewFakeWithChanSize(1, false)
+	defer watcher.Stop()
+	watcher.Action(watch.Bookmark, newTestTableBookmark(map[string]any{metav1.InitialEventsAnnotationKey: "true"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := w.pipeEvents(ctx, watcher, false); err != nil {
+		t.Errorf("want no timeout once the initial events have ended, got: %v", err)
+	}
+}
+
+func TestCanWatchListAfterFallback(t *testing.T) {
+	w := &Watcher{Args: []string{"pods"}, noWatchList: true}
+	if w.canWatchList() {
+		t.Error("want no watch list once the server is known to not support it")
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+)
+
+// MaxBannerLines is the maximum number of warnings shown in the banner
+// above the table. Any additional warnings are summarized on one line.
+const MaxBannerLines = 3
+
+// ShowBanner shows a warning in a banner above the table, until it's
+// dismissed with [KeyMap.DismissBanner]. Warnings that are already shown
+// are ignored.
+func (m *Model) ShowBanner(msg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if msg == "" || slices.Contains(m.banner, msg) {
+		return
+	}
+	m.banner = append(m.banner, msg)
+	m.updatePagination()
+}
+
+// DismissBanner hides all warnings shown by [Model.ShowBanner].
+func (m *Model) DismissBanner() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dismissBanner()
+}
+
+func (m *Model) dismissBanner() {
+	m.banner = nil
+	m.updatePagination()
+}
+
+func (m *Model) bannerHeight() int {
+	if m.maxHeight <= 1 {
+		return 0
+	}
+	if len(m.banner) > MaxBannerLines {
+		return MaxBannerLines + 1
+	}
+	return len(m.banner)
+}
+
+func (m *Model) bannerView(buf *bytes.Buffer) {
+	if m.bannerHeight() == 0 {
+		return
+	}
+	// Newest first, as older warnings are more likely to have been read
+	for i, msg := range slices.Backward(m.banner) {
+		if len(m.banner)-i > MaxBannerLines {
+			more := pluralize(i+1, "more warning", "more warnings")
+			buf.WriteString(m.Styles.Warning.UnsetString().Render("+" + more))
+			break
+		}
+		buf.WriteString(m.Styles.Warning.Render(msg))
+		if i == len(m.banner)-1 {
+			hint := fmt.Sprintf("press %s to dismiss", m.KeyMap.DismissBanner.Help().Key)
+			buf.WriteString(m.Styles.StatusDelim.String())
+			buf.WriteString(m.Styles.Toggles.Render(hint))
+		}
+		buf.WriteByte('\n')
+	}
+	if len(m.banner) > MaxBannerLines {
+		buf.WriteByte('\n')
+	}
+}
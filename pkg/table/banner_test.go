@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestShowBanner(t *testing.T) {
+	m := New()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	m.SetHeaders([]string{"NAME"})
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a"}})
+	m.ShowBanner("policy/v1beta1 PodSecurityPolicy is deprecated")
+	m.ShowBanner("policy/v1beta1 PodSecurityPolicy is deprecated")
+
+	view := m.View()
+	if got := strings.Count(view, "PodSecurityPolicy is deprecated"); got != 1 {
+		t.Errorf("want warning shown once, got %d times:\n%s", got, view)
+	}
+	if !strings.Contains(view, "press x to dismiss") {
+		t.Errorf("want dismiss hint, got:\n%s", view)
+	}
+	if strings.Index(view, "deprecated") > strings.Index(view, "pod-a") {
+		t.Errorf("want banner above the table, got:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if view := m.View(); strings.Contains(view, "deprecated") {
+		t.Errorf("want banner dismissed, got:\n%s", view)
+	}
+}
+
+func TestShowBannerOverflow(t *testing.T) {
+	m := New()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	m.SetHeaders([]string{"NAME"})
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a"}})
+	for i := range MaxBannerLines + 2 {
+		m.ShowBanner(fmt.Sprintf("warning %d", i))
+	}
+
+	view := m.View()
+	for i := range MaxBannerLines + 2 {
+		msg := fmt.Sprintf("warning %d", i)
+		shown := i >= 2
+		if got := strings.Contains(view, msg); got != shown {
+			t.Errorf("want %q shown=%t, got:\n%s", msg, shown, view)
+		}
+	}
+	if !strings.Contains(view, "+2 more warnings") {
+		t.Errorf("want summary of hidden warnings, got:\n%s", view)
+	}
+	if got, want := m.bannerHeight(), MaxBannerLines+1; got != want {
+		t.Errorf("want banner height %d, got %d", want, got)
+	}
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func benchmarkRow(i int, status string) Row {
+	return Row{
+		ID:         fmt.Sprintf("uid-%05d", i),
+		SortKey:    fmt.Sprintf("ns-%02d/pod-%05d", i%20, i),
+		Suggestion: fmt.Sprintf("pod-%05d", i),
+		Fields: []any{
+			fmt.Sprintf("ns-%02d", i%20), fmt.Sprintf("pod-%05d", i),
+			"1/1", status, "0", time.Now().Add(-time.Duration(i) * time.Second),
+		},
+		HasLeadingNamespaceColumn: true,
+	}
+}
+
+func benchmarkModel(b *testing.B, numRows int) *Model {
+	b.Helper()
+	m := New()
+	m.SetHeaders([]string{"NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS", "AGE"})
+	m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	rows := make([]Row, numRows)
+	for i := range rows {
+		rows[i] = benchmarkRow(i, "Running")
+	}
+	m.SetRows(rows)
+	return m
+}
+
+func BenchmarkAddRow(b *testing.B) {
+	for _, numRows := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("update/%d", numRows), func(b *testing.B) {
+			m := benchmarkModel(b, numRows)
+			b.ResetTimer()
+			for i := range b.N {
+				m.AddRow(benchmarkRow(i%numRows, "Running"))
+			}
+		})
+		b.Run(fmt.Sprintf("insert/%d", numRows), func(b *testing.B) {
+			m := benchmarkModel(b, numRows)
+			b.ResetTimer()
+			for i := range b.N {
+				m.AddRow(benchmarkRow(numRows+i, "Pending"))
+			}
+		})
+	}
+}
+
+func BenchmarkTick(b *testing.B) {
+	for _, numRows := range []int{1000, 10000} {
+		b.Run(fmt.Sprint(numRows), func(b *testing.B) {
+			m := benchmarkModel(b, numRows)
+			b.ResetTimer()
+			for range b.N {
+				m.Update(TickMsg(time.Now()))
+				_ = m.View()
+			}
+		})
+	}
+}
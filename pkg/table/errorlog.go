@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MaxErrorLogEntries is the maximum number of entries kept in the error log.
+// Older entries are discarded.
+const MaxErrorLogEntries = 100
+
+// RecentLogEntryDuration is how long the latest error log entry is shown
+// in the status line.
+const RecentLogEntryDuration = 10 * time.Second
+
+// Severity of an [ErrorLogEntry].
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// ErrorLogEntry is an error or warning in the error log. Repeated entries
+// are deduplicated by increasing the Count.
+type ErrorLogEntry struct {
+	// Time is when the entry was last logged.
+	Time     time.Time
+	Severity Severity
+	// Source is where the entry came from, such as "metrics",
+	// or empty if unknown.
+	Source  string
+	Message string
+	Count   int
+
+	seen bool
+}
+
+func (e ErrorLogEntry) String() string {
+	msg := e.Message
+	if e.Source != "" {
+		msg = e.Source + ": " + msg
+	}
+	if e.Count > 1 {
+		msg = fmt.Sprintf("%s (%d times)", msg, e.Count)
+	}
+	return msg
+}
+
+// errorLog holds the error log entries, oldest first.
+type errorLog struct {
+	entries []ErrorLogEntry
+}
+
+func (l *errorLog) add(severity Severity, source, message string, now time.Time) {
+	for i, entry := range l.entries {
+		if entry.Severity != severity || entry.Source != source || entry.Message != message {
+			continue
+		}
+		entry.Count++
+		entry.Time = now
+		entry.seen = false
+		// Move it last, as it's now the latest entry
+		l.entries = append(append(l.entries[:i], l.entries[i+1:]...), entry)
+		return
+	}
+	if len(l.entries) >= MaxErrorLogEntries {
+		l.entries = l.entries[len(l.entries)-MaxErrorLogEntries+1:]
+	}
+	l.entries = append(l.entries, ErrorLogEntry{
+		Time:     now,
+		Severity: severity,
+		Source:   source,
+		Message:  message,
+		Count:    1,
+	})
+}
+
+// recent returns the latest entry, if it was logged recently.
+func (l *errorLog) recent(now time.Time) (ErrorLogEntry, bool) {
+	if len(l.entries) == 0 {
+		return ErrorLogEntry{}, false
+	}
+	latest := l.entries[len(l.entries)-1]
+	if now.Sub(latest.Time) >= RecentLogEntryDuration {
+		return ErrorLogEntry{}, false
+	}
+	return latest, true
+}
+
+// unseen returns the number of entries of each severity that have been
+// logged since the error log was last viewed.
+func (l *errorLog) unseen() (errors, warnings int) {
+	for _, entry := range l.entries {
+		if entry.seen {
+			continue
+		}
+		if entry.Severity == SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+func (l *errorLog) markSeen() {
+	for i := range l.entries {
+		l.entries[i].seen = true
+	}
+}
+
+// badge returns a summary of the unseen entries, such as "2 errors",
+// or an empty string if there are none.
+func (l *errorLog) badge() string {
+	errors, warnings := l.unseen()
+	var parts []string
+	if errors > 0 {
+		parts = append(parts, pluralize(errors, "error", "errors"))
+	}
+	if warnings > 0 {
+		parts = append(parts, pluralize(warnings, "warning", "warnings"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// LogError adds the error to the error log, which is shown by pressing
+// [KeyMap.ShowErrorLog]. The source is where the error came from, such
+// as "metrics", and may be empty. Safe to call from any goroutine.
+func (m *Model) LogError(source string, err error) {
+	if err == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errorLog.add(SeverityError, source, err.Error(), time.Now())
+}
+
+// LogWarning adds the warning to the error log, see [Model.LogError].
+func (m *Model) LogWarning(source, message string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errorLog.add(SeverityWarning, source, message, time.Now())
+}
+
+// ErrorLog returns a copy of the error log entries, oldest first.
+func (m *Model) ErrorLog() []ErrorLogEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ErrorLogEntry(nil), m.errorLog.entries...)
+}
+
+// errorLogPane shows the error log, newest first. It reads the model's log
+// directly, which is safe as panes are only used while the model is locked.
+type errorLogPane struct {
+	styles   *Styles
+	keys     *KeyMap
+	log      *errorLog
+	location *time.Location
+	offset   int
+}
+
+var _ Pane = &errorLogPane{}
+
+func (p *errorLogPane) Init() tea.Cmd {
+	return nil
+}
+
+func (p *errorLogPane) Close() {}
+
+func (p *errorLogPane) Update(msg tea.Msg) (Pane, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+	switch {
+	case key.Matches(keyMsg, p.keys.CursorUp):
+		p.offset--
+	case key.Matches(keyMsg, p.keys.CursorDown):
+		p.offset++
+	case key.Matches(keyMsg, p.keys.GoToStart):
+		p.offset = 0
+	case key.Matches(keyMsg, p.keys.GoToEnd):
+		p.offset = len(p.log.entries)
+	}
+	p.offset = max(min(p.offset, len(p.log.entries)-1), 0)
+	return p, nil
+}
+
+func (p *errorLogPane) View(_, height int) string {
+	p.log.markSeen()
+	var sb strings.Builder
+	sb.WriteString(p.styles.PaneTitle.Render("ERROR LOG:"))
+	if len(p.log.entries) == 0 {
+		sb.WriteByte('\n')
+		sb.WriteString(p.styles.NoneFound.UnsetString().Render("No errors or warnings"))
+		return sb.String()
+	}
+	p.offset = max(min(p.offset, len(p.log.entries)-1), 0)
+	lines := max(height-1, 1)
+	for i := len(p.log.entries) - 1 - p.offset; i >= 0 && lines > 0; i-- {
+		entry := p.log.entries[i]
+		style := p.styles.Error
+		if entry.Severity == SeverityWarning {
+			style = p.styles.Warning
+		}
+		sb.WriteByte('\n')
+		sb.WriteString(p.styles.TimelineTime.Render(entry.Time.In(p.location).Format(time.TimeOnly)))
+		sb.WriteByte(' ')
+		sb.WriteString(style.Render(entry.String()))
+		lines--
+	}
+	return sb.String()
+}
+
+func (m *Model) openErrorLog() tea.Cmd {
+	location := m.TimeLocation
+	if location == nil {
+		location = time.Local
+	}
+	return m.showPane(&errorLogPane{
+		styles:   &m.Styles,
+		keys:     &m.KeyMap,
+		log:      &m.errorLog,
+		location: location,
+	}, "")
+}
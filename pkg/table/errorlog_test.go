@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestErrorLogDeduplicates(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	var log errorLog
+	log.add(SeverityError, "watch", "connection refused", now)
+	log.add(SeverityWarning, "watch", "deprecated API", now.Add(time.Second))
+	log.add(SeverityError, "watch", "connection refused", now.Add(2*time.Second))
+	log.add(SeverityError, "metrics", "connection refused", now.Add(3*time.Second))
+
+	want := []string{
+		"watch: deprecated API",
+		"watch: connection refused (2 times)",
+		"metrics: connection refused",
+	}
+	if len(log.entries) != len(want) {
+		t.Fatalf("want %d entries, got %d: %v", len(want), len(log.entries), log.entries)
+	}
+	for i, entry := range log.entries {
+		if got := entry.String(); got != want[i] {
+			t.Errorf("entry %d: want %q, got %q", i, want[i], got)
+		}
+	}
+	if got, want := log.entries[1].Time, now.Add(2*time.Second); !got.Equal(want) {
+		t.Errorf("want repeated entry time %s, got %s", want, got)
+	}
+}
+
+func TestErrorLogBounded(t *testing.T) {
+	now := time.Now()
+	var log errorLog
+	for i := range MaxErrorLogEntries + 10 {
+		log.add(SeverityError, "", fmt.Sprintf("error %d", i), now)
+	}
+	if len(log.entries) != MaxErrorLogEntries {
+		t.Fatalf("want %d entries, got %d", MaxErrorLogEntries, len(log.entries))
+	}
+	if got, want := log.entries[0].Message, "error 10"; got != want {
+		t.Errorf("want oldest entry %q, got %q", want, got)
+	}
+}
+
+func TestErrorLogBadge(t *testing.T) {
+	now := time.Now()
+	var log errorLog
+	if got := log.badge(); got != "" {
+		t.Errorf("want no badge, got %q", got)
+	}
+	log.add(SeverityError, "", "a", now)
+	log.add(SeverityError, "", "b", now)
+	log.add(SeverityWarning, "", "c", now)
+	if got, want := log.badge(), "2 errors, 1 warning"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	log.markSeen()
+	if got := log.badge(); got != "" {
+		t.Errorf("want no badge after viewing, got %q", got)
+	}
+	// Repeated entries are unseen again
+	log.add(SeverityError, "", "a", now)
+	if got, want := log.badge(), "1 error"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestErrorLogRecent(t *testing.T) {
+	now := time.Now()
+	var log errorLog
+	log.add(SeverityError, "", "a", now)
+	if _, ok := log.recent(now.Add(time.Second)); !ok {
+		t.Error("want recent entry")
+	}
+	if _, ok := log.recent(now.Add(RecentLogEntryDuration)); ok {
+		t.Error("want no recent entry after the duration")
+	}
+}
+
+func TestShowErrorLog(t *testing.T) {
+	m := New()
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	m.SetHeaders([]string{"NAME"})
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a"}})
+	m.LogError("watch", errors.New("connection refused"))
+	m.LogWarning("metrics", "metrics-server unavailable")
+
+	if view := m.View(); !strings.Contains(view, "1 error, 1 warning") {
+		t.Errorf("want badge in status line, got:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	view := m.View()
+	for _, want := range []string{"ERROR LOG:", "watch: connection refused", "metrics: metrics-server unavailable"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want error log to contain %q, got:\n%s", want, view)
+		}
+	}
+	// Newest first
+	if strings.Index(view, "metrics-server") > strings.Index(view, "connection refused") {
+		t.Errorf("want newest entry first, got:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if view := m.View(); strings.Contains(view, "1 error") {
+		t.Errorf("want badge cleared after viewing the log, got:\n%s", view)
+	}
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// ExportFormat is a file format that rows can be exported as.
+type ExportFormat string
+
+const (
+	ExportText     ExportFormat = "text"
+	ExportCSV      ExportFormat = "csv"
+	ExportJSON     ExportFormat = "json"
+	ExportMarkdown ExportFormat = "markdown"
+)
+
+// ExportFormats are all supported export formats.
+var ExportFormats = []ExportFormat{
+	ExportText,
+	ExportCSV,
+	ExportJSON,
+	ExportMarkdown,
+}
+
+// ParseExportFormat returns the export format with the given name.
+func ParseExportFormat(name string) (ExportFormat, error) {
+	for _, format := range ExportFormats {
+		if string(format) == strings.ToLower(name) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown export format: %q, allowed formats are: %s", name, ExportFormats)
+}
+
+// FileExtension returns the file extension used for the format,
+// including the leading dot.
+func (f ExportFormat) FileExtension() string {
+	switch f {
+	case ExportCSV:
+		return ".csv"
+	case ExportJSON:
+		return ".json"
+	case ExportMarkdown:
+		return ".md"
+	default:
+		return ".txt"
+	}
+}
+
+// Export writes the rows in the given format, using the uncolored values
+// of the rows' fields.
+func Export(w io.Writer, format ExportFormat, headers []string, rows []Row) error {
+	switch format {
+	case ExportText:
+		return WriteText(w, headers, rows)
+	case ExportCSV:
+		return WriteCSV(w, headers, rows)
+	case ExportJSON:
+		return WriteJSON(w, headers, rows)
+	case ExportMarkdown:
+		return WriteMarkdown(w, headers, rows)
+	default:
+		return fmt.Errorf("unknown export format: %q", format)
+	}
+}
+
+// WriteText writes the rows as an aligned plain text table, using the
+// uncolored values of the rows' fields.
+func WriteText(w io.Writer, headers []string, rows []Row) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	writeLine := func(fields []string) error {
+		_, err := io.WriteString(tw, strings.Join(fields, "\t")+"\n")
+		return err
+	}
+	if len(headers) > 0 {
+		if err := writeLine(headers); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := writeLine(row.PlainFields()); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// WriteCSV writes the rows as comma separated values, with the headers
+// on the first line.
+func WriteCSV(w io.Writer, headers []string, rows []Row) error {
+	cw := csv.NewWriter(w)
+	if len(headers) > 0 {
+		if err := cw.Write(headers); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := cw.Write(row.PlainFields()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// jsonExport is the output of [WriteJSON]. The rows are arrays in the same
+// order as the headers, instead of objects keyed on the headers, to keep the
+// column order and as headers aren't always unique.
+type jsonExport struct {
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+}
+
+// WriteJSON writes the headers and rows as a JSON object, where each row is
+// an array of its values.
+func WriteJSON(w io.Writer, headers []string, rows []Row) error {
+	export := jsonExport{
+		Headers: headers,
+		Rows:    make([][]string, 0, len(rows)),
+	}
+	if export.Headers == nil {
+		export.Headers = []string{}
+	}
+	for _, row := range rows {
+		export.Rows = append(export.Rows, row.PlainFields())
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(export)
+}
+
+// WriteMarkdown writes the rows as a Markdown table.
+func WriteMarkdown(w io.Writer, headers []string, rows []Row) error {
+	writeLine := func(fields []string) error {
+		escaped := make([]string, len(fields))
+		for i, field := range fields {
+			escaped[i] = strings.ReplaceAll(field, "|", `\|`)
+		}
+		_, err := fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+		return err
+	}
+	if err := writeLine(headers); err != nil {
+		return err
+	}
+	separator := make([]string, len(headers))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	if err := writeLine(separator); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writeLine(row.PlainFields()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	headers := []string{"NAME", "READY", "STATUS"}
+	rows := []Row{
+		{Fields: []any{"my-pod", "1/1", StyledColumn{Value: "Running"}}},
+		{Fields: []any{"my-other-pod", "0/1", JoinedColumn{Delimiter: ", ", Values: []any{"Pending", "Init|Error"}}}},
+	}
+	tests := []struct {
+		format ExportFormat
+		want   string
+	}{
+		{
+			format: ExportText,
+			want: `NAME           READY   STATUS
+my-pod         1/1     Running
+my-other-pod   0/1     Pending, Init|Error
+`,
+		},
+		{
+			format: ExportCSV,
+			want: `NAME,READY,STATUS
+my-pod,1/1,Running
+my-other-pod,0/1,"Pending, Init|Error"
+`,
+		},
+		{
+			format: ExportJSON,
+			want: `{
+  "headers": [
+    "NAME",
+    "READY",
+    "STATUS"
+  ],
+  "rows": [
+    [
+      "my-pod",
+      "1/1",
+      "Running"
+    ],
+    [
+      "my-other-pod",
+      "0/1",
+      "Pending, Init|Error"
+    ]
+  ]
+}
+`,
+		},
+		{
+			format: ExportMarkdown,
+			want: `| NAME | READY | STATUS |
+| --- | --- | --- |
+| my-pod | 1/1 | Running |
+| my-other-pod | 0/1 | Pending, Init\|Error |
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			var sb strings.Builder
+			if err := Export(&sb, test.format, headers, rows); err != nil {
+				t.Fatal(err)
+			}
+			if got := sb.String(); got != test.want {
+				t.Errorf("wrong output\nwant:\n%s\ngot:\n%s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestWriteJSONDuplicateHeaders(t *testing.T) {
+	headers := []string{"NAME", "STATUS", "STATUS"}
+	rows := []Row{{Fields: []any{"my-pod", "Running", "Ready"}}}
+	var sb strings.Builder
+	if err := WriteJSON(&sb, headers, rows); err != nil {
+		t.Fatal(err)
+	}
+	var got jsonExport
+	if err := json.Unmarshal([]byte(sb.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Headers, headers) {
+		t.Errorf("want headers %q, got %q", headers, got.Headers)
+	}
+	if len(got.Rows) != 1 || !slices.Equal(got.Rows[0], []string{"my-pod", "Running", "Ready"}) {
+		t.Errorf("want all values in column order, got %q", got.Rows)
+	}
+}
+
+func TestParseExportFormat(t *testing.T) {
+	if got, err := ParseExportFormat("CSV"); err != nil || got != ExportCSV {
+		t.Errorf("want %q, got %q (err: %v)", ExportCSV, got, err)
+	}
+	if _, err := ParseExportFormat("xml"); err == nil {
+		t.Error("want error for unknown format")
+	}
+}
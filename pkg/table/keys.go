@@ -26,14 +26,28 @@ import (
 // is used to render the menu.
 type KeyMap struct {
 	// Keybindings used when browsing the list.
-	NextPage  key.Binding
-	PrevPage  key.Binding
-	GoToStart key.Binding
-	GoToEnd   key.Binding
+	CursorUp   key.Binding
+	CursorDown key.Binding
+	NextPage   key.Binding
+	PrevPage   key.Binding
+	GoToStart  key.Binding
+	GoToEnd    key.Binding
+
+	// Keybindings for marking rows.
+	ToggleMark    key.Binding
+	ToggleMarkAll key.Binding
+	ExportRows    key.Binding
 
 	// Keybindings for view settings
 	ToggleDeleted    key.Binding
 	ToggleFullscreen key.Binding
+	CycleTimeFormat  key.Binding
+	DismissBanner    key.Binding
+
+	// Keybindings for panes about the selected row.
+	ShowTimeline key.Binding
+	ShowErrorLog key.Binding
+	ClosePane    key.Binding
 
 	// Keybindings used while the text-filter is enabled.
 	Filter           key.Binding
@@ -58,6 +72,14 @@ func (k KeyMap) EscapeFilterText(keyMsg tea.KeyMsg) bool {
 // DefaultKeyMap is a default set of keybindings.
 var DefaultKeyMap = KeyMap{
 	// Browsing.
+	CursorUp: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "move up"),
+	),
+	CursorDown: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "move down"),
+	),
 	PrevPage: key.NewBinding(
 		key.WithKeys("left", "h", "pgup"),
 		key.WithHelp("←/h/pgup", "prev page"),
@@ -74,6 +96,18 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("end", "G"),
 		key.WithHelp("G/end", "go to end"),
 	),
+	ToggleMark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "mark/unmark row"),
+	),
+	ToggleMarkAll: key.NewBinding(
+		key.WithKeys("ctrl+a"),
+		key.WithHelp("ctrl+a", "mark/unmark all visible"),
+	),
+	ExportRows: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "export rows"),
+	),
 	ToggleFullscreen: key.NewBinding(
 		key.WithKeys("f"),
 		key.WithHelp("f", "toggle fullscreen"),
@@ -83,6 +117,28 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("d"),
 		key.WithHelp("d", "show all deleted"),
 	),
+	CycleTimeFormat: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "relative/absolute times"),
+	),
+	DismissBanner: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "dismiss warnings"),
+	),
+
+	// Panes.
+	ShowTimeline: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "show timeline"),
+	),
+	ShowErrorLog: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "show error log"),
+	),
+	ClosePane: key.NewBinding(
+		key.WithKeys("esc", "q"),
+		key.WithHelp("esc/q", "close pane"),
+	),
 
 	// Filtering.
 	Filter: key.NewBinding(
@@ -131,10 +187,14 @@ var DefaultKeyMap = KeyMap{
 // help.KeyMap interface.
 func (m *Model) FullHelp() [][]key.Binding {
 	browsingBindings := [][]key.Binding{{
+		m.KeyMap.CursorUp,
+		m.KeyMap.CursorDown,
 		m.KeyMap.NextPage,
 		m.KeyMap.PrevPage,
 		m.KeyMap.GoToStart,
 		m.KeyMap.GoToEnd,
+		m.KeyMap.ToggleMark,
+		m.KeyMap.ToggleMarkAll,
 	}}
 
 	// filtering := m.filterState == Filtering
@@ -161,11 +221,26 @@ func (m *Model) FullHelp() [][]key.Binding {
 		m.KeyMap.CloseFullHelp,
 		m.KeyMap.ToggleDeleted,
 		m.KeyMap.ToggleFullscreen,
+		m.KeyMap.CycleTimeFormat,
+		m.KeyMap.DismissBanner,
+	}
+	if m.Export != nil {
+		actionsBindings = append(actionsBindings, m.KeyMap.ExportRows)
+	}
+
+	paneBindings := []key.Binding{
+		m.KeyMap.ShowTimeline,
+		m.KeyMap.ShowErrorLog,
+	}
+	for _, binding := range m.PaneBindings {
+		paneBindings = append(paneBindings, binding.Key)
 	}
+	paneBindings = append(paneBindings, m.KeyMap.ClosePane)
 
 	return append(
 		browsingBindings,
 		filterBindings,
 		actionsBindings,
+		paneBindings,
 	)
 }
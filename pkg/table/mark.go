@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+// Marked rows are keyed on [Row.ID], so that they stay marked when the
+// rows are updated or re-sorted.
+
+func (m *Model) isMarked(id string) bool {
+	_, ok := m.marked[id]
+	return ok
+}
+
+func (m *Model) toggleMark(id string) {
+	if m.isMarked(id) {
+		delete(m.marked, id)
+		return
+	}
+	if m.marked == nil {
+		m.marked = map[string]struct{}{}
+	}
+	m.marked[id] = struct{}{}
+}
+
+// toggleMarkAll marks all visible rows, or unmarks them if they are
+// all already marked. Deleted rows can't be marked, and are skipped.
+func (m *Model) toggleMarkAll() {
+	allMarked := true
+	for _, row := range m.filteredRows {
+		if row.Status != StatusDeleted && !m.isMarked(row.ID) {
+			allMarked = false
+			break
+		}
+	}
+	for _, row := range m.filteredRows {
+		if allMarked {
+			delete(m.marked, row.ID)
+		} else if row.Status != StatusDeleted {
+			if m.marked == nil {
+				m.marked = map[string]struct{}{}
+			}
+			m.marked[row.ID] = struct{}{}
+		}
+	}
+}
+
+// pruneMarked unmarks rows that have been deleted or removed.
+func (m *Model) pruneMarked() {
+	if len(m.marked) == 0 {
+		return
+	}
+	exists := make(map[string]struct{}, len(m.marked))
+	for _, row := range m.rows {
+		if row.Status != StatusDeleted {
+			exists[row.ID] = struct{}{}
+		}
+	}
+	for id := range m.marked {
+		if _, ok := exists[id]; !ok {
+			delete(m.marked, id)
+		}
+	}
+}
+
+// MarkedRows returns all marked rows, in the same order as they are shown.
+// Marked rows that are hidden by the filter are included as well.
+func (m *Model) MarkedRows() []Row {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.markedRows()
+}
+
+func (m *Model) markedRows() []Row {
+	if len(m.marked) == 0 {
+		return nil
+	}
+	rows := make([]Row, 0, len(m.marked))
+	for _, row := range m.rows {
+		if m.isMarked(row.ID) {
+			rows = append(rows, *row)
+		}
+	}
+	return rows
+}
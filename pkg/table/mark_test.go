@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func markedIDs(m *Model) string {
+	var ids []string
+	for _, row := range m.MarkedRows() {
+		ids = append(ids, row.ID)
+	}
+	return fmt.Sprint(ids)
+}
+
+func TestToggleMark(t *testing.T) {
+	m := New()
+	m.AddRow(Row{ID: "b", Fields: []any{"pod-b"}})
+	m.AddRow(Row{ID: "c", Fields: []any{"pod-c"}})
+
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	m.Update(space) // marks "b" and moves to "c"
+	m.Update(space) // marks "c"
+	if got, want := markedIDs(m), "[b c]"; got != want {
+		t.Fatalf("wrong marked rows\nwant: %s\ngot:  %s", want, got)
+	}
+
+	// Marks should survive re-sorting and updates
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a"}})
+	m.AddRow(Row{ID: "c", Fields: []any{"pod-c"}, State: "Running"})
+	if got, want := markedIDs(m), "[b c]"; got != want {
+		t.Errorf("wrong marked rows after update\nwant: %s\ngot:  %s", want, got)
+	}
+
+	// Deleted rows should no longer be marked
+	deleted := Row{ID: "b", Fields: []any{"pod-b"}}
+	deleted.MarkDeleted()
+	m.AddRow(deleted)
+	if got, want := markedIDs(m), "[c]"; got != want {
+		t.Errorf("wrong marked rows after delete\nwant: %s\ngot:  %s", want, got)
+	}
+}
+
+func TestToggleMarkAll(t *testing.T) {
+	m := New()
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a"}})
+	m.AddRow(Row{ID: "b", Fields: []any{"pod-b"}})
+	m.AddRow(Row{ID: "c", Fields: []any{"other-c"}})
+
+	m.filterInput.SetValue("pod")
+	m.updateRows()
+
+	ctrlA := tea.KeyMsg{Type: tea.KeyCtrlA}
+	m.Update(ctrlA)
+	if got, want := markedIDs(m), "[a b]"; got != want {
+		t.Fatalf("want all visible rows marked\nwant: %s\ngot:  %s", want, got)
+	}
+	m.Update(ctrlA)
+	if got, want := markedIDs(m), "[]"; got != want {
+		t.Errorf("want all visible rows unmarked\nwant: %s\ngot:  %s", want, got)
+	}
+}
+
+func TestToggleMarkAllWithDeletedRow(t *testing.T) {
+	m := New()
+	m.ShowDeleted = true
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a"}})
+	deleted := Row{ID: "b", Fields: []any{"pod-b"}}
+	deleted.MarkDeleted()
+	m.AddRow(deleted)
+	m.AddRow(Row{ID: "c", Fields: []any{"pod-c"}})
+
+	ctrlA := tea.KeyMsg{Type: tea.KeyCtrlA}
+	m.Update(ctrlA)
+	if got, want := markedIDs(m), "[a c]"; got != want {
+		t.Fatalf("want all visible non-deleted rows marked\nwant: %s\ngot:  %s", want, got)
+	}
+	m.Update(ctrlA)
+	if got, want := markedIDs(m), "[]"; got != want {
+		t.Errorf("want all visible rows unmarked\nwant: %s\ngot:  %s", want, got)
+	}
+}
+
+func TestSetRowsPrunesMarked(t *testing.T) {
+	m := New()
+	m.SetRows([]Row{
+		{ID: "a", Fields: []any{"pod-a"}},
+		{ID: "b", Fields: []any{"pod-b"}},
+	})
+	m.toggleMarkAll()
+	m.SetRows([]Row{{ID: "b", Fields: []any{"pod-b"}}})
+	if got, want := markedIDs(m), "[b]"; got != want {
+		t.Errorf("wrong marked rows\nwant: %s\ngot:  %s", want, got)
+	}
+}
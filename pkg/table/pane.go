@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Pane is a view shown below the selected row in place of the other rows,
+// such as a popup with details about the selected row.
+//
+// While a pane is open, all key presses except [KeyMap.ClosePane],
+// [KeyMap.ShowFullHelp] and [KeyMap.ForceQuit] are sent to the pane,
+// as well as all messages that the table itself does not handle.
+type Pane interface {
+	Init() tea.Cmd
+	Update(msg tea.Msg) (Pane, tea.Cmd)
+	View(width, height int) string
+	// Close is called when the pane is closed, and should release any
+	// resources held by the pane, such as stopping background goroutines.
+	Close()
+}
+
+// RowPane is an optional interface for panes that want to be notified
+// whenever the row they were opened for is updated.
+type RowPane interface {
+	Pane
+	SetRow(row Row) tea.Cmd
+}
+
+// InputPane is an optional interface for panes that sometimes need all
+// key presses, such as while a text input inside the pane is focused.
+type InputPane interface {
+	Pane
+	// CapturesInput returns true if all key presses except
+	// [KeyMap.ForceQuit] should be sent to the pane.
+	CapturesInput() bool
+}
+
+func (m *Model) paneCapturesInput() bool {
+	inputPane, ok := m.pane.(InputPane)
+	return ok && inputPane.CapturesInput()
+}
+
+// PaneBinding opens a pane for the selected row when its key is pressed.
+type PaneBinding struct {
+	Key key.Binding
+	// Open returns a new pane for the row, or nil if the pane does not
+	// apply to the row.
+	Open func(row Row) Pane
+	// OpenMarked is optional, and is used instead of Open while any rows
+	// are marked, to open a pane for all of the marked rows at once.
+	OpenMarked func(rows []Row) Pane
+}
+
+// OpenPane opens a pane for the currently selected row, closing any
+// previously opened pane.
+func (m *Model) OpenPane(open func(row Row) Pane) tea.Cmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.openPane(open)
+}
+
+func (m *Model) openPane(open func(row Row) Pane) tea.Cmd {
+	m.cursorVisible = true
+	row, ok := m.selectedRow()
+	if !ok {
+		return nil
+	}
+	cmd := m.showPane(open(row), row.ID)
+	return tea.Batch(cmd, m.updatePaneRow(row))
+}
+
+// showPane replaces any opened pane with the new pane. The rowID is the
+// row that the pane is about, or empty if it is about multiple rows.
+func (m *Model) showPane(pane Pane, rowID string) tea.Cmd {
+	if pane == nil {
+		return nil
+	}
+	m.closePane()
+	m.filterInputEnabled = false
+	m.pane = pane
+	m.paneRowID = rowID
+	return pane.Init()
+}
+
+// ClosePane closes the currently opened pane, if any.
+func (m *Model) ClosePane() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closePane()
+}
+
+func (m *Model) closePane() {
+	if m.pane == nil {
+		return
+	}
+	m.pane.Close()
+	m.pane = nil
+	m.paneRowID = ""
+}
+
+func (m *Model) updatePaneRow(row Row) tea.Cmd {
+	if m.pane == nil || m.paneRowID != row.ID {
+		return nil
+	}
+	rowPane, ok := m.pane.(RowPane)
+	if !ok {
+		return nil
+	}
+	return rowPane.SetRow(row)
+}
@@ -52,7 +52,7 @@ func TestRace_AddRowWhileUpdate(t *testing.T) {
 	var wg sync.WaitGroup
 
 	// Simulate the Kubernetes watch goroutine: call AddRow, SetHeaders,
-	// SetError, SetRows, StartSpinner, StopSpinner concurrently.
+	// LogError, SetRows, StartSpinner, StopSpinner concurrently.
 	// This is what happens in klock.go watch() and pipeEvents().
 	for w := 0; w < numWriters; w++ {
 		wg.Add(1)
@@ -71,10 +71,10 @@ func TestRace_AddRowWhileUpdate(t *testing.T) {
 					m.SetHeaders([]string{"NAME", "READY", "STATUS", "RESTARTS", "AGE"})
 				}
 				if i%70 == 0 {
-					m.SetError(fmt.Errorf("transient error %d", i))
+					m.LogError("watch", fmt.Errorf("transient error %d", i))
 				}
 				if i%71 == 0 {
-					m.SetError(nil)
+					m.LogWarning("watch", fmt.Sprintf("transient warning %d", i))
 				}
 				if i%100 == 0 {
 					m.StopSpinner()
@@ -19,6 +19,7 @@ package table
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -28,15 +29,19 @@ import (
 )
 
 type RowStyles struct {
-	Cell    lipgloss.Style
-	Error   lipgloss.Style
-	Deleted lipgloss.Style
+	Cell     lipgloss.Style
+	Error    lipgloss.Style
+	Deleted  lipgloss.Style
+	Selected lipgloss.Style
+	Marked   lipgloss.Style
 }
 
 var DefaultRowStyle = RowStyles{
-	Cell:    lipgloss.NewStyle(),
-	Error:   lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
-	Deleted: lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+	Cell:     lipgloss.NewStyle(),
+	Error:    lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+	Deleted:  lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+	Selected: lipgloss.NewStyle().Reverse(true),
+	Marked:   lipgloss.NewStyle().Foreground(lipgloss.Color("5")).Bold(true),
 }
 
 type StyledColumn struct {
@@ -49,14 +54,52 @@ type JoinedColumn struct {
 	Values    []any
 }
 
+// DynamicColumn is a column whose value is looked up every time the row is
+// rendered, for values that are updated without the row itself changing.
+type DynamicColumn struct {
+	Value func() any
+}
+
 type AgoColumn struct {
 	Value string
 	Time  time.Time
 }
 
 func (c AgoColumn) String() string {
-	dur := time.Since(c.Time)
-	return fmt.Sprintf("%s (%s ago)", c.Value, duration.HumanDuration(dur))
+	return c.format(timeFormatter{})
+}
+
+func (c AgoColumn) format(f timeFormatter) string {
+	return fmt.Sprintf("%s (%s)", c.Value, f.formatAgo(c.Time))
+}
+
+// SparklineColumn renders the recent history of a numeric value as a small
+// unicode bar chart, such as "▁▁▃▇". The history is carried over when the row
+// is updated via [Model.AddRow], and a new sample is taken both then and
+// every [Model.SparklineInterval].
+type SparklineColumn struct {
+	// Sample returns the current value, or false if there is none.
+	Sample func() (float64, bool)
+	// Values are the sampled values, oldest first.
+	Values []float64
+
+	sampledAt time.Time
+}
+
+func (c SparklineColumn) String() string {
+	if len(c.Values) == 0 {
+		return ""
+	}
+	low, high := slices.Min(c.Values), slices.Max(c.Values)
+	var sb strings.Builder
+	for _, v := range c.Values {
+		index := 0
+		if high > low {
+			index = int((v-low)/(high-low)*float64(len(sparklineBars)-1) + 0.5)
+		}
+		sb.WriteRune(sparklineBars[index])
+	}
+	return sb.String()
 }
 
 type Row struct {
@@ -67,10 +110,34 @@ type Row struct {
 	SortKey    string
 	Suggestion string
 
+	// State is a short description of the row's current state, such as
+	// a pod's status. Changes to it are recorded in the row's Timeline.
+	State    string
+	Timeline []TimelineEntry
+	// ObservedAt is when the row's State was observed, such as when the
+	// watch event was received. Defaults to when the row is added.
+	ObservedAt time.Time
+
+	// Object is the source object that the row was created from,
+	// such as a Kubernetes resource. Used by panes about the row.
+	Object any
+
 	Kubecolor                 *config.Config
 	HasLeadingNamespaceColumn bool
+	HasLeadingContextColumn   bool
 
+	// timeFormat is set by the [Model] that the row is added to.
+	timeFormat     timeFormatter
 	renderedFields []string
+	// stale is set when the rendered fields are outdated, such as when
+	// the time has passed. They are re-rendered the next time they're used.
+	stale bool
+	// hasSparklines is set when the row has any [SparklineColumn] fields.
+	hasSparklines bool
+	// filterFields are the plain fields that the filter text is matched
+	// against. Unlike the rendered fields they're only updated when the row
+	// changes, so filtering doesn't re-render every row as time passes.
+	filterFields []string
 }
 
 type Status int
@@ -98,7 +165,7 @@ func (r Row) SortValue() string {
 }
 
 func (r *Row) RenderedFields() []string {
-	if len(r.renderedFields) != len(r.Fields) {
+	if r.stale || len(r.renderedFields) != len(r.Fields) {
 		r.ReRenderFields()
 	}
 	return r.renderedFields
@@ -111,16 +178,38 @@ func (r *Row) ReRenderFields() {
 	rendered := resizeSlice(r.renderedFields, len(r.Fields))
 	offset := 0
 	if r.HasLeadingNamespaceColumn {
-		offset = -1
+		offset--
+	}
+	if r.HasLeadingContextColumn {
+		offset--
 	}
 	cfg := r.Kubecolor
 	if r.Status == StatusDeleted {
 		cfg = nil
 	}
 	for i, col := range r.Fields {
-		rendered[i] = renderColumn(col, i+offset, cfg)
+		rendered[i] = renderColumn(col, i+offset, cfg, r.timeFormat)
 	}
 	r.renderedFields = rendered
+	r.stale = false
+}
+
+// cachedFilterFields returns the plain fields that the filter text is
+// matched against, see [Row.filterFields].
+func (r *Row) cachedFilterFields() []string {
+	if r.filterFields == nil {
+		r.filterFields = r.PlainFields()
+	}
+	return r.filterFields
+}
+
+// PlainFields returns the fields rendered as text, without any styling.
+func (r Row) PlainFields() []string {
+	fields := make([]string, len(r.Fields))
+	for i, col := range r.Fields {
+		fields[i] = plainColumn(col, r.timeFormat)
+	}
+	return fields
 }
 
 func (r *Row) MarkDeleted() {
@@ -131,7 +220,7 @@ func (r *Row) MarkDeleted() {
 	r.DeletedAt = time.Now()
 }
 
-func renderColumn(value any, index int, cfg *config.Config) string {
+func renderColumn(value any, index int, cfg *config.Config, f timeFormatter) string {
 	switch value := value.(type) {
 	case JoinedColumn:
 		var sb strings.Builder
@@ -139,24 +228,27 @@ func renderColumn(value any, index int, cfg *config.Config) string {
 			if i > 0 {
 				sb.WriteString(value.Delimiter)
 			}
-			sb.WriteString(renderColumn(v, index, cfg))
+			sb.WriteString(renderColumn(v, index, cfg, f))
 		}
 		return sb.String()
+	case DynamicColumn:
+		return renderColumn(value.Value(), index, cfg, f)
 	case StyledColumn:
 		if cfg != nil && value.Style.GetForeground() == (lipgloss.NoColor{}) {
-			return value.Style.Render(renderColumn(value.Value, index, cfg))
+			return value.Style.Render(renderColumn(value.Value, index, cfg, f))
 		} else {
-			return value.Style.Render(renderColumn(value.Value, index, nil))
+			return value.Style.Render(renderColumn(value.Value, index, nil, f))
 		}
 	case string:
 		return colorFromColumn(value, index, cfg)
 	case time.Time:
-		dur := time.Since(value)
-		str := duration.HumanDuration(dur)
-		if cfg != nil && cfg.ObjFreshThreshold > 0 && time.Since(value) <= cfg.ObjFreshThreshold {
+		str := f.format(value)
+		if cfg != nil && cfg.ObjFreshThreshold > 0 && time.Since(value) >= 0 && time.Since(value) <= cfg.ObjFreshThreshold {
 			return cfg.Theme.Data.DurationFresh.Render(str)
 		}
 		return colorFromColumn(str, index, cfg)
+	case AgoColumn:
+		return value.format(f)
 	case fmt.Stringer:
 		return value.String()
 	default:
@@ -167,6 +259,46 @@ func renderColumn(value any, index int, cfg *config.Config) string {
 	}
 }
 
+func plainColumn(value any, f timeFormatter) string {
+	switch value := value.(type) {
+	case JoinedColumn:
+		var sb strings.Builder
+		for i, v := range value.Values {
+			if i > 0 {
+				sb.WriteString(value.Delimiter)
+			}
+			sb.WriteString(plainColumn(v, f))
+		}
+		return sb.String()
+	case DynamicColumn:
+		return plainColumn(value.Value(), f)
+	case StyledColumn:
+		return plainColumn(value.Value, f)
+	case string:
+		return value
+	case time.Time:
+		return f.format(value)
+	case AgoColumn:
+		return value.format(f)
+	case fmt.Stringer:
+		return value.String()
+	default:
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	}
+}
+
+// humanTime returns the age of the time, e.g "5m",
+// or a countdown if it's in the future, e.g "in 5m".
+func humanTime(t time.Time) string {
+	if until := time.Until(t); until > 0 {
+		return "in " + duration.HumanDuration(until)
+	}
+	return duration.HumanDuration(time.Since(t))
+}
+
 func colorFromColumn(s string, index int, cfg *config.Config) string {
 	if cfg == nil {
 		return s
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// The rows are kept sorted by [compareRows] in both [Model.rows] and
+// [Model.filteredRows], so that a single row can be found, inserted, or
+// removed using a binary search when it's updated, instead of re-sorting
+// and re-filtering the whole table on every watch event.
+
+// compareRows orders rows by their [Row.SortValue], and then by their ID
+// so that the order is the same no matter in which order they were added.
+func compareRows(a, b *Row) int {
+	return cmp.Or(
+		cmp.Compare(a.SortValue(), b.SortValue()),
+		cmp.Compare(a.ID, b.ID),
+	)
+}
+
+// indexSorted returns the index of the row in the sorted slice,
+// or -1 if it's not in there.
+func indexSorted(rows []*Row, row *Row) int {
+	i, found := slices.BinarySearchFunc(rows, row, compareRows)
+	if !found || rows[i] != row {
+		return -1
+	}
+	return i
+}
+
+// insertSorted inserts the row into the sorted slice.
+func insertSorted(rows []*Row, row *Row) []*Row {
+	i, _ := slices.BinarySearchFunc(rows, row, compareRows)
+	return slices.Insert(rows, i, row)
+}
+
+// removeSorted removes the row from the sorted slice, if it's in there.
+func removeSorted(rows []*Row, row *Row) []*Row {
+	i := indexSorted(rows, row)
+	if i == -1 {
+		return rows
+	}
+	return slices.Delete(rows, i, i+1)
+}
+
+// derefRows returns copies of the rows.
+func derefRows(rows []*Row) []Row {
+	copies := make([]Row, len(rows))
+	for i, row := range rows {
+		copies[i] = *row
+	}
+	return copies
+}
+
+// rowVisible returns false if the row is hidden by the filter text, or
+// because it has been deleted for longer than [Model.HideDeletedAfter].
+// Deleted rows that are still visible update [Model.nextHideAt], so that
+// the rows only have to be re-filtered once one of them should be hidden.
+func (m *Model) rowVisible(row *Row, filterText string, now time.Time) bool {
+	if dur, hasDur := m.HideDeletedAfter.Duration(); hasDur &&
+		!m.ShowDeleted &&
+		row.Status == StatusDeleted {
+		hideAt := row.DeletedAt.Add(dur)
+		if !now.Before(hideAt) {
+			return false
+		}
+		if m.nextHideAt.IsZero() || hideAt.Before(m.nextHideAt) {
+			m.nextHideAt = hideAt
+		}
+	}
+	if filterText != "" && !rowMatchesText(row, filterText) {
+		return false
+	}
+	return true
+}
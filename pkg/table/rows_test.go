@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/applejag/kubectl-klock/pkg/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func rowIDs(rows []*Row) []string {
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	return ids
+}
+
+func TestAddRowKeepsSorted(t *testing.T) {
+	m := New()
+	m.AddRow(Row{ID: "c", SortKey: "2"})
+	m.AddRow(Row{ID: "a", SortKey: "3"})
+	m.AddRow(Row{ID: "b", SortKey: "1"})
+	m.AddRow(Row{ID: "d", SortKey: "2"})
+	if got, want := rowIDs(m.rows), []string{"b", "c", "d", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	// Changing the sort key moves the row
+	m.AddRow(Row{ID: "a", SortKey: "0"})
+	if got, want := rowIDs(m.rows), []string{"a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if got, want := rowIDs(m.filteredRows), []string{"a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("want filtered %v, got %v", want, got)
+	}
+	if got := m.RowIndex("c"); got != 2 {
+		t.Errorf("want index 2, got %d", got)
+	}
+}
+
+func TestAddRowFiltered(t *testing.T) {
+	m := New()
+	m.filterInput.SetValue("web")
+	m.AddRow(Row{ID: "1", Fields: []any{"web-1"}})
+	m.AddRow(Row{ID: "2", Fields: []any{"db-1"}})
+	m.AddRow(Row{ID: "3", Fields: []any{"web-2"}})
+	if got, want := rowIDs(m.filteredRows), []string{"1", "3"}; !slices.Equal(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	// Updating a row so it no longer matches hides it
+	m.AddRow(Row{ID: "1", Fields: []any{"api-1"}, SortKey: "web-1"})
+	if got, want := rowIDs(m.filteredRows), []string{"3"}; !slices.Equal(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestTickHidesDeletedRows(t *testing.T) {
+	m := New()
+	m.HideDeletedAfter = types.NewOptionalDuration(time.Minute)
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a"}})
+	m.AddRow(Row{ID: "b", Fields: []any{"pod-b"}, Status: StatusDeleted, DeletedAt: time.Now()})
+	if got, want := rowIDs(m.filteredRows), []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	m.nextHideAt = time.Now().Add(-time.Second)
+	m.index["b"].DeletedAt = time.Now().Add(-2 * time.Minute)
+	m.Update(TickMsg(time.Now()))
+	if got, want := rowIDs(m.filteredRows), []string{"a"}; !slices.Equal(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if !m.nextHideAt.IsZero() {
+		t.Errorf("want no next hide time, got %s", m.nextHideAt)
+	}
+}
+
+func TestTickRendersOnlyVisibleRows(t *testing.T) {
+	m := New()
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 4})
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		m.AddRow(Row{ID: id, Fields: []any{"pod-" + id}})
+	}
+	m.Update(TickMsg(time.Now()))
+	for i, row := range m.rows {
+		visible := i < m.Paginator.PerPage
+		if row.stale == visible {
+			t.Errorf("row %q: want stale=%t, got %t", row.ID, !visible, row.stale)
+		}
+	}
+}
+
+func TestTickWithFilterRendersOnlyVisibleRows(t *testing.T) {
+	m := New()
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 4})
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		m.AddRow(Row{ID: id, Fields: []any{"pod-" + id}})
+	}
+	m.filterInput.SetValue("pod")
+	m.updateRows()
+	m.Update(TickMsg(time.Now()))
+	if len(m.filteredRows) != 5 {
+		t.Fatalf("want all rows to match the filter, got %d", len(m.filteredRows))
+	}
+	for i, row := range m.rows {
+		visible := i < m.Paginator.PerPage
+		if row.stale == visible {
+			t.Errorf("row %q: want stale=%t, got %t", row.ID, !visible, row.stale)
+		}
+	}
+}
+
+func TestFilterMatchesUpdatedRow(t *testing.T) {
+	m := New()
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a", "Pending"}})
+	m.filterInput.SetValue("Running")
+	m.updateRows()
+	if len(m.filteredRows) != 0 {
+		t.Fatalf("want no rows matching the filter, got %d", len(m.filteredRows))
+	}
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a", "Running"}})
+	if len(m.filteredRows) != 1 {
+		t.Errorf("want the updated row to match the filter, got %d rows", len(m.filteredRows))
+	}
+}
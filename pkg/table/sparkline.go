@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"slices"
+	"time"
+)
+
+// MaxSparklineValues is the maximum number of values kept in a
+// [SparklineColumn]. Older values are discarded.
+const MaxSparklineValues = 12
+
+var sparklineBars = []rune("▁▂▃▄▅▆▇█")
+
+// sample adds the current value to the sparkline's history.
+func (c SparklineColumn) sample(now time.Time) SparklineColumn {
+	c.sampledAt = now
+	if c.Sample == nil {
+		return c
+	}
+	value, ok := c.Sample()
+	if !ok {
+		return c
+	}
+	if len(c.Values) >= MaxSparklineValues {
+		// Copy instead of reslicing, so the old row's values
+		// (which may still be rendered) are left untouched.
+		c.Values = append([]float64(nil), c.Values[len(c.Values)-MaxSparklineValues+1:]...)
+	} else {
+		c.Values = c.Values[:len(c.Values):len(c.Values)]
+	}
+	c.Values = append(c.Values, value)
+	return c
+}
+
+// sampleSparklines takes a new sample for all sparkline columns in the row,
+// continuing the history from the previous version of the row, if any.
+// Deleted rows only keep their history.
+func (r *Row) sampleSparklines(prev *Row, now time.Time) {
+	cloned := false
+	for i, field := range r.Fields {
+		col, ok := field.(SparklineColumn)
+		if !ok {
+			continue
+		}
+		if prev != nil && i < len(prev.Fields) {
+			if prevCol, ok := prev.Fields[i].(SparklineColumn); ok {
+				col.Values = prevCol.Values
+				col.sampledAt = prevCol.sampledAt
+			}
+		}
+		if r.Status != StatusDeleted {
+			col = col.sample(now)
+		}
+		if !cloned {
+			// The table owns the fields from here on, as they are
+			// updated in place by [Row.tickSparklines].
+			r.Fields = slices.Clone(r.Fields)
+			cloned = true
+		}
+		r.Fields[i] = col
+	}
+	r.hasSparklines = cloned
+}
+
+// tickSparklines samples the row's sparkline columns that haven't been
+// sampled within the interval.
+func (r *Row) tickSparklines(now time.Time, interval time.Duration) {
+	if !r.hasSparklines || r.Status == StatusDeleted {
+		return
+	}
+	for i, field := range r.Fields {
+		col, ok := field.(SparklineColumn)
+		if !ok || now.Sub(col.sampledAt) < interval {
+			continue
+		}
+		r.Fields[i] = col.sample(now)
+	}
+}
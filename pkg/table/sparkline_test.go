@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSparklineColumnString(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   string
+	}{
+		{name: "empty", values: nil, want: ""},
+		{name: "flat", values: []float64{3, 3, 3}, want: "▁▁▁"},
+		{name: "rising", values: []float64{0, 1, 2, 3, 4, 5, 6, 7}, want: "▁▂▃▄▅▆▇█"},
+		{name: "spike", values: []float64{10, 10, 50, 10}, want: "▁▁█▁"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := (SparklineColumn{Values: test.values}).String(); got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestSparklineColumnSampleBounded(t *testing.T) {
+	value := 0.0
+	col := SparklineColumn{Sample: func() (float64, bool) {
+		value++
+		return value, true
+	}}
+	now := time.Now()
+	for range MaxSparklineValues + 3 {
+		col = col.sample(now)
+	}
+	if len(col.Values) != MaxSparklineValues {
+		t.Fatalf("want %d values, got %d", MaxSparklineValues, len(col.Values))
+	}
+	if got, want := col.Values[0], 4.0; got != want {
+		t.Errorf("wrong oldest value, want %v, got %v", want, got)
+	}
+}
+
+func TestAddRowKeepsSparkline(t *testing.T) {
+	sparkline := func(value float64) SparklineColumn {
+		return SparklineColumn{Sample: func() (float64, bool) { return value, true }}
+	}
+	m := New()
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a", sparkline(1)}})
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a", sparkline(2)}})
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a", sparkline(2)}})
+
+	row, ok := m.SelectedRow()
+	if !ok {
+		t.Fatal("want a selected row")
+	}
+	if got, want := row.PlainFields()[1], "▁██"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	m.SparklineInterval = time.Minute
+	m.Update(TickMsg(time.Now()))
+	row, _ = m.SelectedRow()
+	if got, want := row.PlainFields()[1], "▁██"; got != want {
+		t.Errorf("want no sample before the interval\nwant: %q\ngot:  %q", want, got)
+	}
+	m.Update(TickMsg(time.Now().Add(time.Minute)))
+	row, _ = m.SelectedRow()
+	if got, want := row.PlainFields()[1], "▁███"; got != want {
+		t.Errorf("want a sample after the interval\nwant: %q\ngot:  %q", want, got)
+	}
+}
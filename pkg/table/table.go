@@ -19,7 +19,6 @@ package table
 
 import (
 	"bytes"
-	"cmp"
 	"fmt"
 	"slices"
 	"strings"
@@ -45,6 +44,7 @@ type Styles struct {
 
 	NoneFound         lipgloss.Style
 	Error             lipgloss.Style
+	Warning           lipgloss.Style
 	Pagination        lipgloss.Style
 	FilterPrompt      lipgloss.Style
 	FilterInfo        lipgloss.Style
@@ -52,6 +52,10 @@ type Styles struct {
 	StatusDelim       lipgloss.Style
 
 	Toggles lipgloss.Style
+
+	PaneTitle     lipgloss.Style
+	TimelineArrow lipgloss.Style
+	TimelineTime  lipgloss.Style
 }
 
 var subduedColor = lipgloss.AdaptiveColor{Light: "#9B9B9B", Dark: "#5C5C5C"}
@@ -66,6 +70,9 @@ var DefaultStyles = Styles{
 	Error: lipgloss.NewStyle().
 		Foreground(lipgloss.ANSIColor(9)).
 		SetString("ERROR:"),
+	Warning: lipgloss.NewStyle().
+		Foreground(lipgloss.ANSIColor(3)).
+		SetString("WARNING:"),
 	Pagination: lipgloss.NewStyle().
 		Foreground(subduedColor).
 		SetString("PAGE:"),
@@ -85,6 +92,15 @@ var DefaultStyles = Styles{
 
 	Toggles: lipgloss.NewStyle().
 		Foreground(subduedColor),
+
+	PaneTitle: lipgloss.NewStyle().
+		Foreground(subduedColor).
+		Bold(true),
+	TimelineArrow: lipgloss.NewStyle().
+		Foreground(subduedColor).
+		SetString(" → "),
+	TimelineTime: lipgloss.NewStyle().
+		Foreground(subduedColor),
 }
 
 type Model struct {
@@ -94,6 +110,32 @@ type Model struct {
 	HideDeletedAfter types.OptionalDuration
 	ShowHelp         bool
 
+	// TimeFormat is how time values are shown, such as in the AGE column.
+	// Cycled through with [KeyMap.CycleTimeFormat].
+	TimeFormat TimeFormat
+	// TimeLocation is the time zone used for absolute times.
+	// Defaults to the local time zone.
+	TimeLocation *time.Location
+
+	// DebugInfo, if set, returns text that is shown last in the status line.
+	DebugInfo func() string
+
+	// SparklineInterval is how often [SparklineColumn] fields are sampled,
+	// in addition to every time their row is updated.
+	SparklineInterval time.Duration
+
+	// StateStyle is used to color the states in a row's timeline.
+	StateStyle func(state string) lipgloss.Style
+
+	// PaneBindings are additional panes that can be opened for the
+	// selected row.
+	PaneBindings []PaneBinding
+
+	// Export, if set, returns a pane that exports the given rows when
+	// [KeyMap.ExportRows] is pressed. The rows are the marked rows,
+	// or all visible rows if no rows are marked.
+	Export func(headers []string, rows []Row) Pane
+
 	// Key mappings for navigating the list.
 	KeyMap KeyMap
 
@@ -105,23 +147,53 @@ type Model struct {
 
 	// mu protects all mutable state below (and the fields above that are
 	// written after construction) from concurrent access.  The Kubernetes
-	// watch goroutine calls AddRow/SetRows/SetHeaders/SetError/StartSpinner/
+	// watch goroutine calls AddRow/SetRows/SetHeaders/LogError/StartSpinner/
 	// StopSpinner while the bubbletea event loop calls Update/View on the
 	// main goroutine.
 	// See: https://github.com/applejag/kubectl-klock/issues/161
 	mu sync.Mutex
 
-	err                 error
+	errorLog            errorLog
+	banner              []string
+	sources             []sourceStatus
 	headers             []string
 	maxHeight           int
-	rows                []Row
-	filteredRows        []Row
+	maxWidth            int
+	rows                []*Row
+	filteredRows        []*Row
 	columnWidths        []int
 	fullscreenOverride  bool
 	quitting            bool
 	prevSuggestionCount int
 
+	// index is used to look up rows by their [Row.ID].
+	index map[string]*Row
+	// nextHideAt is when the next deleted row should be hidden,
+	// see [Model.rowVisible].
+	nextHideAt time.Time
+	// suggestionsDirty is set when the rows have changed since the filter
+	// suggestions were last updated.
+	suggestionsDirty bool
+
 	filterInputEnabled bool
+
+	// loaded and loadingTotal are the progress of loading the initial rows,
+	// see [Model.SetLoading].
+	loading      bool
+	loaded       int
+	loadingTotal int
+
+	// cursor is the index of the selected row in filteredRows, and
+	// selectedID is used to keep the same row selected when rows are
+	// added, removed, or re-sorted.
+	cursor        int
+	selectedID    string
+	cursorVisible bool
+
+	pane      Pane
+	paneRowID string
+
+	marked map[string]struct{}
 }
 
 func New() *Model {
@@ -131,6 +203,9 @@ func New() *Model {
 		Paginator:   paginator.New(),
 		CellSpacing: 3,
 
+		TimeFormat:        TimeRelative,
+		SparklineInterval: 10 * time.Second,
+
 		help:    help.New(),
 		spinner: spinner.New(spinner.WithSpinner(spinner.Dot)),
 
@@ -150,36 +225,85 @@ func (m *Model) RowIndex(id string) int {
 }
 
 func (m *Model) rowIndex(id string) int {
-	for i, row := range m.rows {
-		if row.ID == id {
-			return i
-		}
+	row, ok := m.index[id]
+	if !ok {
+		return -1
 	}
-	return -1
+	return indexSorted(m.rows, row)
 }
 
 func (m *Model) AddRow(row Row) tea.Cmd {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	index := m.rowIndex(row.ID)
-	if index == -1 {
-		m.rows = append(m.rows, row)
+	now := time.Now()
+	observedAt := row.ObservedAt
+	if observedAt.IsZero() {
+		observedAt = now
+	}
+	row.timeFormat = m.timeFormatter()
+	row.filterFields = nil
+	if prev, ok := m.index[row.ID]; ok {
+		row.Timeline = appendTimeline(prev.Timeline, row.State, observedAt)
+		row.sampleSparklines(prev, now)
+		m.rows = removeSorted(m.rows, prev)
+		m.filteredRows = removeSorted(m.filteredRows, prev)
 	} else {
-		m.rows[index] = row
+		row.Timeline = appendTimeline(row.Timeline, row.State, observedAt)
+		row.sampleSparklines(nil, now)
+	}
+	if row.Status == StatusDeleted {
+		delete(m.marked, row.ID)
 	}
 
-	m.sortItems()
+	added := &row
+	if m.index == nil {
+		m.index = map[string]*Row{}
+	}
+	m.index[row.ID] = added
+	m.rows = insertSorted(m.rows, added)
+	if m.rowVisible(added, m.filterText(), now) {
+		m.filteredRows = insertSorted(m.filteredRows, added)
+	}
+	m.suggestionsDirty = true
+
 	m.stopSpinner()
-	m.updateRows()
+	m.updateCursor()
+	m.updatePagination()
+	m.updateColumnWidths()
 	fullscreenCmd := m.updateFullscreenCmd()
-	return fullscreenCmd
+	return tea.Batch(fullscreenCmd, m.updatePaneRow(row))
+}
+
+func (m *Model) timeFormatter() timeFormatter {
+	return timeFormatter{Format: m.TimeFormat, Location: m.TimeLocation}
+}
+
+// applyTimeFormat updates all rows to use the current time format.
+func (m *Model) applyTimeFormat() {
+	f := m.timeFormatter()
+	for _, row := range m.rows {
+		row.timeFormat = f
+		row.stale = true
+		row.filterFields = nil
+	}
 }
 
 func (m *Model) SetRows(rows []Row) tea.Cmd {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.rows = slices.Clone(rows)
-	m.sortItems()
+	m.rows = make([]*Row, len(rows))
+	m.index = make(map[string]*Row, len(rows))
+	now := time.Now()
+	for i := range rows {
+		row := rows[i]
+		row.filterFields = nil
+		row.sampleSparklines(nil, now)
+		m.rows[i] = &row
+		m.index[row.ID] = &row
+	}
+	m.applyTimeFormat()
+	slices.SortFunc(m.rows, compareRows)
+	m.pruneMarked()
 	if len(m.rows) > 0 {
 		m.stopSpinner()
 	}
@@ -196,23 +320,59 @@ func (m *Model) updateRows() {
 
 func (m *Model) updateFilteredRows() {
 	filterText := m.filterText()
-	m.filteredRows = make([]Row, 0, len(m.rows))
+	now := time.Now()
+	m.nextHideAt = time.Time{}
+	m.filteredRows = make([]*Row, 0, len(m.rows))
 	for _, row := range m.rows {
-		if dur, hasDur := m.HideDeletedAfter.Duration(); hasDur &&
-			!m.ShowDeleted &&
-			row.Status == StatusDeleted &&
-			time.Since(row.DeletedAt) >= dur {
-			continue
+		if m.rowVisible(row, filterText, now) {
+			m.filteredRows = append(m.filteredRows, row)
 		}
-		if filterText != "" && !rowMatchesText(row, filterText) {
-			continue
+	}
+	m.updateCursor()
+}
+
+func (m *Model) updateCursor() {
+	if row, ok := m.index[m.selectedID]; ok {
+		if i := indexSorted(m.filteredRows, row); i != -1 {
+			m.cursor = i
+			return
 		}
-		m.filteredRows = append(m.filteredRows, row)
+	}
+	m.cursor = max(min(m.cursor, len(m.filteredRows)-1), 0)
+	if len(m.filteredRows) > 0 {
+		m.selectedID = m.filteredRows[m.cursor].ID
+	} else {
+		m.selectedID = ""
 	}
 }
 
-func rowMatchesText(row Row, needle string) bool {
-	for _, field := range row.RenderedFields() {
+func (m *Model) moveCursor(index int) {
+	m.cursorVisible = true
+	if len(m.filteredRows) == 0 {
+		return
+	}
+	m.cursor = max(min(index, len(m.filteredRows)-1), 0)
+	m.selectedID = m.filteredRows[m.cursor].ID
+	m.updatePagination()
+	m.updateColumnWidths()
+}
+
+func (m *Model) selectedRow() (Row, bool) {
+	if len(m.filteredRows) == 0 {
+		return Row{}, false
+	}
+	return *m.filteredRows[m.cursor], true
+}
+
+// SelectedRow returns the row under the cursor, if any.
+func (m *Model) SelectedRow() (Row, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.selectedRow()
+}
+
+func rowMatchesText(row *Row, needle string) bool {
+	for _, field := range row.cachedFilterFields() {
 		if strings.Contains(field, needle) {
 			return true
 		}
@@ -238,12 +398,64 @@ func (m *Model) updateFilterSuggestions() {
 
 	m.prevSuggestionCount = len(suggestions)
 	m.filterInput.SetSuggestions(suggestions)
+	m.suggestionsDirty = false
 }
 
-func (m *Model) SetError(err error) {
+// sourceStatus is the status of a source of rows, see [Model.SetSourceStatus].
+type sourceStatus struct {
+	name   string
+	status string
+	err    error
+}
+
+// SetSourceStatus sets the status shown in the status line for a source of
+// rows, such as a cluster context when watching multiple contexts at once.
+// A non-nil error is shown instead of the status.
+func (m *Model) SetSourceStatus(source, status string, err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.err = err
+	for i := range m.sources {
+		if m.sources[i].name == source {
+			m.sources[i].status = status
+			m.sources[i].err = err
+			return
+		}
+	}
+	m.sources = append(m.sources, sourceStatus{name: source, status: status, err: err})
+}
+
+// DeleteRowsFunc removes all rows for which del returns true.
+// Unlike rows marked as deleted, they are removed right away.
+func (m *Model) DeleteRowsFunc(del func(row Row) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rows = slices.DeleteFunc(m.rows, func(row *Row) bool {
+		if !del(*row) {
+			return false
+		}
+		delete(m.index, row.ID)
+		return true
+	})
+	m.pruneMarked()
+	m.updateRows()
+}
+
+// SetLoading shows a "loading N/M" indicator in the status line, for while
+// the initial rows are loaded in chunks. A total of zero means the total is
+// unknown. Use [Model.StopLoading] to hide it again.
+func (m *Model) SetLoading(loaded, total int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loading = true
+	m.loaded = loaded
+	m.loadingTotal = total
+}
+
+// StopLoading hides the indicator shown by [Model.SetLoading].
+func (m *Model) StopLoading() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loading = false
 }
 
 func (m *Model) updateFullscreenCmd() tea.Cmd {
@@ -261,24 +473,23 @@ func (m *Model) paginatorVisible() bool {
 }
 
 func (m *Model) windowTooShort() bool {
-	height := len(m.filteredRows) + 1 // +1 for header
-	if m.err != nil {
+	height := len(m.filteredRows) + 1 + m.bannerHeight() // +1 for header
+	if _, ok := m.errorLog.recent(time.Now()); ok {
 		height++
 	}
 	return height > m.maxHeight
 }
 
-func (m *Model) sortItems() {
-	slices.SortStableFunc(m.rows, func(a, b Row) int {
-		return cmp.Compare(a.SortValue(), b.SortValue())
-	})
-}
-
 func (m *Model) updatePagination() {
-	perPage := max(m.maxHeight-2, 1) // 1 for header & 1 for paginator
+	perPage := max(m.maxHeight-2-m.bannerHeight(), 1) // 1 for header & 1 for paginator
 	m.Paginator.PerPage = perPage
 	m.Paginator.SetTotalPages(len(m.filteredRows))
 
+	// Keep the selected row visible
+	if m.cursorVisible {
+		m.Paginator.Page = m.cursor / perPage
+	}
+
 	// Make sure the page stays in bounds
 	if m.Paginator.Page >= m.Paginator.TotalPages-1 {
 		m.Paginator.Page = m.Paginator.TotalPages - 1
@@ -342,6 +553,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.filterInput.KeyMap.NextSuggestion = m.KeyMap.NextSuggestion
 			m.filterInput.KeyMap.PrevSuggestion = m.KeyMap.PrevSuggestion
 			m.filterInput.KeyMap.AcceptSuggestion = m.KeyMap.AcceptSuggestion
+			if m.suggestionsDirty {
+				m.updateFilterSuggestions()
+			}
 			i, cmd := m.filterInput.Update(msg)
 			m.filterInput = i
 			m.updateRows()
@@ -349,14 +563,82 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.KeyMap.ForceQuit):
 			m.quitting = true
 			return m, tea.Quit
+		case m.pane != nil && m.paneCapturesInput():
+			pane, cmd := m.pane.Update(msg)
+			m.pane = pane
+			return m, cmd
+		case !m.ShowHelp && key.Matches(msg, m.KeyMap.ShowFullHelp):
+			m.ShowHelp = true
+			return m, nil
+		case m.ShowHelp && key.Matches(msg, m.KeyMap.CloseFullHelp):
+			m.ShowHelp = false
+			return m, nil
+		case m.pane != nil && key.Matches(msg, m.KeyMap.ClosePane):
+			m.closePane()
+			return m, m.updateFullscreenCmd()
+		case m.pane != nil:
+			pane, cmd := m.pane.Update(msg)
+			m.pane = pane
+			return m, cmd
+		case key.Matches(msg, m.KeyMap.CursorUp):
+			m.moveCursor(m.cursor - 1)
+			return m, nil
+		case key.Matches(msg, m.KeyMap.CursorDown):
+			m.moveCursor(m.cursor + 1)
+			return m, nil
+		case key.Matches(msg, m.KeyMap.GoToStart):
+			m.moveCursor(0)
+			return m, nil
+		case key.Matches(msg, m.KeyMap.GoToEnd):
+			m.moveCursor(len(m.filteredRows) - 1)
+			return m, nil
 		case key.Matches(msg, m.KeyMap.PrevPage):
 			m.Paginator.PrevPage()
+			if m.cursorVisible {
+				m.moveCursor(m.Paginator.Page * m.Paginator.PerPage)
+			}
 			m.updateColumnWidths()
 			return m, nil
 		case key.Matches(msg, m.KeyMap.NextPage):
 			m.Paginator.NextPage()
+			if m.cursorVisible {
+				m.moveCursor(m.Paginator.Page * m.Paginator.PerPage)
+			}
 			m.updateColumnWidths()
 			return m, nil
+		case key.Matches(msg, m.KeyMap.ToggleMark):
+			m.cursorVisible = true
+			if row, ok := m.selectedRow(); ok && row.Status != StatusDeleted {
+				m.toggleMark(row.ID)
+				m.moveCursor(m.cursor + 1)
+			}
+			return m, nil
+		case key.Matches(msg, m.KeyMap.ToggleMarkAll):
+			m.toggleMarkAll()
+			return m, nil
+		case m.Export != nil && key.Matches(msg, m.KeyMap.ExportRows):
+			rows := m.markedRows()
+			if len(rows) == 0 {
+				rows = derefRows(m.filteredRows)
+			}
+			return m, m.showPane(m.Export(slices.Clone(m.headers), rows), "")
+		case key.Matches(msg, m.KeyMap.ShowTimeline):
+			return m, m.openPane(func(Row) Pane {
+				return &timelinePane{
+					styles:     &m.Styles,
+					stateStyle: m.StateStyle,
+				}
+			})
+		case key.Matches(msg, m.KeyMap.ShowErrorLog):
+			return m, m.openErrorLog()
+		case len(m.banner) > 0 && key.Matches(msg, m.KeyMap.DismissBanner):
+			m.dismissBanner()
+			return m, m.updateFullscreenCmd()
+		case key.Matches(msg, m.KeyMap.CycleTimeFormat):
+			m.TimeFormat = m.TimeFormat.next()
+			m.applyTimeFormat()
+			m.updateRows()
+			return m, nil
 		case key.Matches(msg, m.KeyMap.ToggleDeleted):
 			m.ShowDeleted = !m.ShowDeleted
 			m.updateRows()
@@ -364,12 +646,6 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.KeyMap.ToggleFullscreen):
 			m.fullscreenOverride = !m.fullscreenOverride
 			return m, m.updateFullscreenCmd()
-		case !m.ShowHelp && key.Matches(msg, m.KeyMap.ShowFullHelp):
-			m.ShowHelp = true
-			return m, nil
-		case m.ShowHelp && key.Matches(msg, m.KeyMap.CloseFullHelp):
-			m.ShowHelp = false
-			return m, nil
 		case key.Matches(msg, m.KeyMap.CloseFilter):
 			m.filterInputEnabled = false
 		case key.Matches(msg, m.KeyMap.ClearFilter):
@@ -381,6 +657,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateRows()
 			return m, m.filterInput.Focus()
 		}
+		for _, binding := range m.PaneBindings {
+			if !key.Matches(msg, binding.Key) {
+				continue
+			}
+			if marked := m.markedRows(); len(marked) > 0 && binding.OpenMarked != nil {
+				return m, m.showPane(binding.OpenMarked(marked), "")
+			}
+			return m, m.openPane(binding.Open)
+		}
 	case spinner.TickMsg:
 		s, cmd := m.spinner.Update(msg)
 		m.spinner = s
@@ -388,19 +673,34 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 	case TickMsg:
-		m.updateFilteredRows()
-		m.updatePagination()
-		for i := range m.rows {
-			m.rows[i].ReRenderFields()
+		now := time.Time(msg)
+		for _, row := range m.rows {
+			row.tickSparklines(now, m.SparklineInterval)
+			// Only the visible rows are re-rendered right away, the rest
+			// are re-rendered once they're shown or filtered on.
+			row.stale = true
 		}
+		if m.filterText() != "" ||
+			(!m.nextHideAt.IsZero() && !time.Now().Before(m.nextHideAt)) {
+			m.updateFilteredRows()
+		}
+		m.updatePagination()
 		m.updateColumnWidths()
 		return m, doTick()
 
 	case tea.WindowSizeMsg:
 		m.maxHeight = msg.Height
+		m.maxWidth = msg.Width
 		m.help.Width = msg.Width
 		m.updatePagination()
 		return m, m.updateFullscreenCmd()
+
+	default:
+		if m.pane != nil {
+			pane, cmd := m.pane.Update(msg)
+			m.pane = pane
+			return m, cmd
+		}
 	}
 	return m, nil
 }
@@ -416,10 +716,19 @@ func (m *Model) View() string {
 	}
 	var buf bytes.Buffer
 
+	if m.pane != nil {
+		m.paneView(&buf)
+		return buf.String()
+	}
+
 	currentPage := m.currentPaginatedPage()
+	m.bannerView(&buf)
 
 	if m.maxHeight > 1 {
 		if m.filterInputEnabled {
+			if m.suggestionsDirty {
+				m.updateFilterSuggestions()
+			}
 			m.filterInput.Prompt = ""
 			m.filterInput.PromptStyle = m.Styles.FilterPrompt
 			buf.WriteString(m.filterInput.View())
@@ -453,8 +762,39 @@ func (m *Model) View() string {
 		status = append(status, m.Styles.FilterNoneVisible.String())
 	}
 
-	if m.err != nil {
-		status = append(status, m.Styles.Error.Render(m.err.Error()))
+	if entry, ok := m.errorLog.recent(time.Now()); ok {
+		style := m.Styles.Error
+		if entry.Severity == SeverityWarning {
+			style = m.Styles.Warning
+		}
+		status = append(status, style.Render(entry.String()))
+	}
+	if badge := m.errorLog.badge(); badge != "" {
+		status = append(status, m.Styles.Error.UnsetString().Render(badge))
+	}
+
+	for _, source := range m.sources {
+		if source.err != nil {
+			status = append(status, m.Styles.Error.Render(fmt.Sprintf("%s: %s", source.name, source.err)))
+		} else {
+			status = append(status, m.Styles.Toggles.Render(fmt.Sprintf("%s: %s", source.name, source.status)))
+		}
+	}
+
+	if m.loading {
+		if m.loadingTotal > 0 {
+			status = append(status, m.Styles.Toggles.Render(fmt.Sprintf("loading %d/%d", m.loaded, m.loadingTotal)))
+		} else {
+			status = append(status, m.Styles.Toggles.Render(fmt.Sprintf("loading %d", m.loaded)))
+		}
+	}
+
+	if len(m.marked) > 0 {
+		status = append(status, m.Styles.Toggles.Render(fmt.Sprintf("%d marked", len(m.marked))))
+	}
+
+	if m.TimeFormat != "" && m.TimeFormat != TimeRelative {
+		status = append(status, m.Styles.Toggles.Render(fmt.Sprintf("%s times", m.TimeFormat)))
 	}
 
 	if m.fullscreenOverride {
@@ -471,6 +811,10 @@ func (m *Model) View() string {
 		}
 	}
 
+	if m.DebugInfo != nil {
+		status = append(status, m.Styles.Toggles.Render(m.DebugInfo()))
+	}
+
 	if len(status) > 0 {
 		if len(currentPage) > 0 {
 			buf.WriteByte('\n')
@@ -485,7 +829,7 @@ func (m *Model) View() string {
 	return buf.String()
 }
 
-func (m *Model) currentPaginatedPage() []Row {
+func (m *Model) currentPaginatedPage() []*Row {
 	if len(m.filteredRows) == 0 {
 		return nil
 	}
@@ -493,16 +837,32 @@ func (m *Model) currentPaginatedPage() []Row {
 	return m.filteredRows[start:end]
 }
 
-func (m *Model) viewWriteRows(buf *bytes.Buffer, currentPage []Row) {
+func (m *Model) viewWriteRows(buf *bytes.Buffer, currentPage []*Row) {
+	pageStart, _ := m.Paginator.GetSliceBounds(len(m.filteredRows))
 	for i, row := range currentPage {
 		if i > 0 {
 			buf.WriteByte('\n')
 		}
-		m.rowView(buf, row)
+		m.rowView(buf, row, m.cursorVisible && pageStart+i == m.cursor)
 	}
 }
 
-func (m *Model) rowView(buf *bytes.Buffer, row Row) {
+func (m *Model) rowView(buf *bytes.Buffer, row *Row, selected bool) {
+	marked := m.isMarked(row.ID)
+	if selected || marked {
+		var line bytes.Buffer
+		cellStyle := lipgloss.NewStyle()
+		if marked {
+			cellStyle = m.Styles.Row.Marked
+		}
+		m.columnsView(&line, row.PlainFields(), cellStyle)
+		if selected {
+			buf.WriteString(m.Styles.Row.Selected.Render(line.String()))
+		} else {
+			buf.Write(line.Bytes())
+		}
+		return
+	}
 	style := m.Styles.Row.Cell
 	switch row.Status {
 	case StatusError:
@@ -513,6 +873,26 @@ func (m *Model) rowView(buf *bytes.Buffer, row Row) {
 	m.columnsView(buf, row.RenderedFields(), style)
 }
 
+func (m *Model) paneView(buf *bytes.Buffer) {
+	height := m.maxHeight
+	if m.maxHeight > 1 {
+		m.columnsView(buf, m.headers, m.Styles.Header)
+		buf.WriteByte('\n')
+		height--
+	}
+	if row, ok := m.index[m.paneRowID]; ok {
+		m.rowView(buf, row, false)
+		buf.WriteByte('\n')
+		height--
+	}
+	buf.WriteByte('\n')
+	height--
+	buf.WriteString(m.pane.View(m.maxWidth, height))
+	if m.quitting {
+		buf.WriteByte('\n')
+	}
+}
+
 var lotsOfSpaces = strings.Repeat(" ", 200)
 
 func (m *Model) columnsView(buf *bytes.Buffer, columns []string, style lipgloss.Style) {
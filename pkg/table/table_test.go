@@ -18,8 +18,72 @@
 package table
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
 func Test(t *testing.T) {
 }
+
+func TestCursorFollowsRowWhenResorted(t *testing.T) {
+	m := New()
+	m.AddRow(Row{ID: "b", Fields: []any{"pod-b"}})
+	m.AddRow(Row{ID: "c", Fields: []any{"pod-c"}})
+	m.moveCursor(1)
+	if row, _ := m.SelectedRow(); row.ID != "c" {
+		t.Fatalf("want row %q selected, got %q", "c", row.ID)
+	}
+
+	// Inserting a row before the selected one should not move the selection
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a"}})
+	if row, _ := m.SelectedRow(); row.ID != "c" {
+		t.Errorf("want row %q selected, got %q", "c", row.ID)
+	}
+}
+
+func TestSetLoading(t *testing.T) {
+	tests := []struct {
+		name   string
+		loaded int
+		total  int
+		want   string
+	}{
+		{name: "known total", loaded: 500, total: 20000, want: "loading 500/20000"},
+		{name: "unknown total", loaded: 500, want: "loading 500"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := New()
+			m.SetHeaders([]string{"NAME"})
+			m.AddRow(Row{ID: "a", Fields: []any{"pod-a"}})
+			m.SetLoading(tc.loaded, tc.total)
+			if view := m.View(); !strings.Contains(view, tc.want) {
+				t.Errorf("want status line to contain %q, got:\n%s", tc.want, view)
+			}
+			m.StopLoading()
+			if view := m.View(); strings.Contains(view, "loading") {
+				t.Errorf("want no loading indicator after StopLoading, got:\n%s", view)
+			}
+		})
+	}
+}
+
+func TestSetSourceStatus(t *testing.T) {
+	m := New()
+	m.SetHeaders([]string{"CONTEXT", "NAME"})
+	m.AddRow(Row{ID: "a", Fields: []any{"prod", "pod-a"}})
+	m.SetSourceStatus("prod", "watching", nil)
+	m.SetSourceStatus("dev", "connecting", nil)
+	m.SetSourceStatus("dev", "", errors.New("connection refused"))
+
+	view := m.View()
+	for _, want := range []string{"prod: watching", "dev: connection refused"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want status line to contain %q, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "dev: connecting") {
+		t.Errorf("want error to replace status, got:\n%s", view)
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TimeFormat is how time values, such as the AGE column, are shown.
+type TimeFormat string
+
+const (
+	// TimeRelative shows how long ago it was, e.g "5m".
+	TimeRelative TimeFormat = "relative"
+	// TimeAbsolute shows the timestamp, e.g "2026-01-02T12:03:10Z".
+	TimeAbsolute TimeFormat = "absolute"
+	// TimeBoth shows both, e.g "5m (12:03:10)".
+	TimeBoth TimeFormat = "both"
+)
+
+// TimeFormats are all supported time formats, in the order they are
+// cycled through with [KeyMap.CycleTimeFormat].
+var TimeFormats = []TimeFormat{
+	TimeRelative,
+	TimeAbsolute,
+	TimeBoth,
+}
+
+// ParseTimeFormat returns the time format with the given name.
+func ParseTimeFormat(name string) (TimeFormat, error) {
+	for _, format := range TimeFormats {
+		if string(format) == strings.ToLower(name) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown time format: %q, allowed formats are: %s", name, TimeFormats)
+}
+
+func (f TimeFormat) next() TimeFormat {
+	if f == "" {
+		f = TimeRelative
+	}
+	index := slices.Index(TimeFormats, f)
+	return TimeFormats[(index+1)%len(TimeFormats)]
+}
+
+// timeFormatter formats time values in a row. The zero value uses
+// [TimeRelative] in the local time zone.
+type timeFormatter struct {
+	Format   TimeFormat
+	Location *time.Location
+}
+
+func (f timeFormatter) location() *time.Location {
+	if f.Location == nil {
+		return time.Local
+	}
+	return f.Location
+}
+
+// format returns the time as configured. Relative times that are in the
+// future are shown as a countdown, e.g "in 5m".
+func (f timeFormatter) format(t time.Time) string {
+	switch f.Format {
+	case TimeAbsolute:
+		return f.absolute(t)
+	case TimeBoth:
+		return fmt.Sprintf("%s (%s)", humanTime(t), f.short(t))
+	default:
+		return humanTime(t)
+	}
+}
+
+// formatAgo is like format, but for times that are only shown relatively
+// as "5m ago", such as in [AgoColumn].
+func (f timeFormatter) formatAgo(t time.Time) string {
+	switch f.Format {
+	case TimeAbsolute:
+		return f.absolute(t)
+	case TimeBoth:
+		return fmt.Sprintf("%s ago, %s", humanTime(t), f.short(t))
+	default:
+		return humanTime(t) + " ago"
+	}
+}
+
+func (f timeFormatter) absolute(t time.Time) string {
+	return t.In(f.location()).Format(time.RFC3339)
+}
+
+// short returns only the time of day if it's today, or else the date too.
+func (f timeFormatter) short(t time.Time) string {
+	t = t.In(f.location())
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := time.Now().In(f.location()).Date()
+	if y1 == y2 && m1 == m2 && d1 == d2 {
+		return t.Format(time.TimeOnly)
+	}
+	return t.Format(time.DateTime)
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2023 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTimeFormatter(t *testing.T) {
+	utc := time.UTC
+	tm := time.Date(2026, 1, 2, 12, 3, 10, 0, utc)
+	today := time.Now().In(utc).Add(-5 * time.Minute)
+	tests := []struct {
+		name string
+		f    timeFormatter
+		time time.Time
+		want string
+	}{
+		{name: "absolute", f: timeFormatter{Format: TimeAbsolute, Location: utc}, time: tm, want: "2026-01-02T12:03:10Z"},
+		{name: "absolute in time zone", f: timeFormatter{Format: TimeAbsolute, Location: time.FixedZone("CET", 3600)}, time: tm, want: "2026-01-02T13:03:10+01:00"},
+		{name: "relative", f: timeFormatter{Format: TimeRelative}, time: today, want: "5m"},
+		{name: "both today", f: timeFormatter{Format: TimeBoth, Location: utc}, time: today, want: "5m (" + timeFormatter{Location: utc}.short(today) + ")"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.f.format(test.time); got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestAgoColumnTimeFormat(t *testing.T) {
+	tm := time.Date(2026, 1, 2, 12, 3, 10, 0, time.UTC)
+	col := AgoColumn{Value: "Deleted", Time: tm}
+	f := timeFormatter{Format: TimeAbsolute, Location: time.UTC}
+	if got, want := plainColumn(col, f), "Deleted (2026-01-02T12:03:10Z)"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestCycleTimeFormat(t *testing.T) {
+	tm := time.Date(2026, 1, 2, 12, 3, 10, 0, time.UTC)
+	m := New()
+	m.TimeLocation = time.UTC
+	m.AddRow(Row{ID: "a", Fields: []any{"pod-a", tm}})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	if m.TimeFormat != TimeAbsolute {
+		t.Fatalf("want %q, got %q", TimeAbsolute, m.TimeFormat)
+	}
+	row, ok := m.SelectedRow()
+	if !ok {
+		t.Fatal("want a selected row")
+	}
+	if got, want := row.RenderedFields()[1], "2026-01-02T12:03:10Z"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	if m.TimeFormat != TimeRelative {
+		t.Errorf("want to cycle back to %q, got %q", TimeRelative, m.TimeFormat)
+	}
+}
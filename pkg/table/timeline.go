@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MaxTimelineEntries is the maximum number of state transitions kept
+// in a row's timeline. Older entries are discarded.
+const MaxTimelineEntries = 20
+
+// TimelineEntry is a single state transition of a row.
+type TimelineEntry struct {
+	State string
+	Time  time.Time
+}
+
+// appendTimeline adds the row's current state to the timeline, unless the
+// state is unset or is the same as the latest entry.
+func appendTimeline(timeline []TimelineEntry, state string, now time.Time) []TimelineEntry {
+	if state == "" {
+		return timeline
+	}
+	if len(timeline) > 0 && timeline[len(timeline)-1].State == state {
+		return timeline
+	}
+	if len(timeline) >= MaxTimelineEntries {
+		// Copy instead of reslicing, so the old row's timeline
+		// (which may still be rendered) is left untouched.
+		timeline = append([]TimelineEntry(nil), timeline[len(timeline)-MaxTimelineEntries+1:]...)
+	} else {
+		timeline = timeline[:len(timeline):len(timeline)]
+	}
+	return append(timeline, TimelineEntry{State: state, Time: now})
+}
+
+type timelinePane struct {
+	styles     *Styles
+	stateStyle func(state string) lipgloss.Style
+	row        Row
+}
+
+var _ RowPane = &timelinePane{}
+
+func (p *timelinePane) Init() tea.Cmd {
+	return nil
+}
+
+func (p *timelinePane) Update(tea.Msg) (Pane, tea.Cmd) {
+	return p, nil
+}
+
+func (p *timelinePane) SetRow(row Row) tea.Cmd {
+	p.row = row
+	return nil
+}
+
+func (p *timelinePane) Close() {}
+
+func (p *timelinePane) View(width, _ int) string {
+	var sb strings.Builder
+	sb.WriteString(p.styles.PaneTitle.Render("TIMELINE:"))
+	sb.WriteByte('\n')
+	if len(p.row.Timeline) == 0 {
+		sb.WriteString(p.styles.NoneFound.UnsetString().Render("No state transitions recorded"))
+		return sb.String()
+	}
+	arrow := p.styles.TimelineArrow.String()
+	lineWidth := 0
+	for i, entry := range p.row.Timeline {
+		state := entry.State
+		if p.stateStyle != nil {
+			state = p.stateStyle(state).Render(state)
+		}
+		text := state + " " + p.styles.TimelineTime.Render(entry.Time.In(p.row.timeFormat.location()).Format(time.TimeOnly))
+		textWidth := lipgloss.Width(text)
+		if i > 0 {
+			if width > 0 && lineWidth+lipgloss.Width(arrow)+textWidth > width {
+				sb.WriteByte('\n')
+				sb.WriteString(strings.TrimLeft(arrow, " "))
+				lineWidth = lipgloss.Width(strings.TrimLeft(arrow, " "))
+			} else {
+				sb.WriteString(arrow)
+				lineWidth += lipgloss.Width(arrow)
+			}
+		}
+		sb.WriteString(text)
+		lineWidth += textWidth
+	}
+	return sb.String()
+}
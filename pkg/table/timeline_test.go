@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2026 Kalle Fagerberg
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the
+// Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful, but WITHOUT
+// ANY WARRANTY; without even the implied warranty of MERCHANTABILITY or
+// FITNESS FOR A PARTICULAR PURPOSE.  See the GNU General Public License for
+// more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestAppendTimeline(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 1, 3, 0, time.UTC)
+
+	var timeline []TimelineEntry
+	timeline = appendTimeline(timeline, "Pending", now)
+	timeline = appendTimeline(timeline, "Pending", now.Add(time.Second))
+	timeline = appendTimeline(timeline, "", now.Add(2*time.Second))
+	timeline = appendTimeline(timeline, "Running", now.Add(3*time.Second))
+
+	want := []TimelineEntry{
+		{State: "Pending", Time: now},
+		{State: "Running", Time: now.Add(3 * time.Second)},
+	}
+	if len(timeline) != len(want) {
+		t.Fatalf("want %d entries, got %d: %v", len(want), len(timeline), timeline)
+	}
+	for i := range want {
+		if timeline[i] != want[i] {
+			t.Errorf("entry %d\nwant: %v\ngot:  %v", i, want[i], timeline[i])
+		}
+	}
+}
+
+func TestAppendTimelineBounded(t *testing.T) {
+	now := time.Now()
+	var timeline []TimelineEntry
+	for i := range MaxTimelineEntries + 5 {
+		timeline = appendTimeline(timeline, fmt.Sprint("state-", i), now)
+	}
+	if len(timeline) != MaxTimelineEntries {
+		t.Fatalf("want %d entries, got %d", MaxTimelineEntries, len(timeline))
+	}
+	if got, want := timeline[0].State, "state-5"; got != want {
+		t.Errorf("wrong oldest entry\nwant: %q\ngot:  %q", want, got)
+	}
+	if got, want := timeline[len(timeline)-1].State, fmt.Sprint("state-", MaxTimelineEntries+4); got != want {
+		t.Errorf("wrong newest entry\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+func TestAddRowKeepsTimeline(t *testing.T) {
+	m := New()
+	m.AddRow(Row{ID: "a", State: "Pending", Fields: []any{"pod-a"}})
+	m.AddRow(Row{ID: "a", State: "Running", Fields: []any{"pod-a"}})
+	m.AddRow(Row{ID: "a", State: "Running", Fields: []any{"pod-a"}})
+
+	row, ok := m.SelectedRow()
+	if !ok {
+		t.Fatal("want a selected row")
+	}
+	var states []string
+	for _, entry := range row.Timeline {
+		states = append(states, entry.State)
+	}
+	if got, want := fmt.Sprint(states), "[Pending Running]"; got != want {
+		t.Errorf("wrong timeline\nwant: %s\ngot:  %s", want, got)
+	}
+}
+
+func TestAddRowTimelineUsesObservedAt(t *testing.T) {
+	start := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	m := New()
+	m.AddRow(Row{ID: "a", State: "Pending", Fields: []any{"pod-a"}, ObservedAt: start})
+	m.AddRow(Row{ID: "a", State: "Running", Fields: []any{"pod-a"}, ObservedAt: start.Add(3 * time.Second)})
+
+	row, ok := m.SelectedRow()
+	if !ok {
+		t.Fatal("want a selected row")
+	}
+	want := []TimelineEntry{
+		{State: "Pending", Time: start},
+		{State: "Running", Time: start.Add(3 * time.Second)},
+	}
+	if len(row.Timeline) != len(want) {
+		t.Fatalf("want %d entries, got %d: %v", len(want), len(row.Timeline), row.Timeline)
+	}
+	for i := range want {
+		if row.Timeline[i] != want[i] {
+			t.Errorf("entry %d\nwant: %v\ngot:  %v", i, want[i], row.Timeline[i])
+		}
+	}
+}